@@ -0,0 +1,23 @@
+package lexer
+
+import "unicode/utf8"
+
+// asciiSet is a bitmap of ASCII characters.
+type asciiSet [2]uint64
+
+// makeASCIISet returns the set of chars, ok is false if chars
+// contains non ASCII characters.
+func makeASCIISet(chars string) (s asciiSet, ok bool) {
+	for i := 0; i < len(chars); i++ {
+		c := chars[i]
+		if c >= utf8.RuneSelf {
+			return s, false
+		}
+		s[c/64] |= 1 << (c % 64)
+	}
+	return s, true
+}
+
+func (s *asciiSet) contains(r rune) bool {
+	return r >= 0 && r < utf8.RuneSelf && s[r/64]&(1<<(uint(r)%64)) != 0
+}
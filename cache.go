@@ -0,0 +1,132 @@
+package lexer
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// cacheFormat identifies the encoding of the cached tokens,
+// it is part of the keys so that a change invalidates the caches.
+const cacheFormat = "gob/1"
+
+// CacheStore stores the entries of a Cache.
+type CacheStore interface {
+	// Load returns the data stored with key, ok is false if there is none.
+	Load(key string) (data []byte, ok bool, err error)
+	// Store stores data with key.
+	Store(key string, data []byte) error
+}
+
+// Cache maps the content of the sources to their tokens,
+// so the unchanged sources are not lexed again.
+// It is safe for concurrent use if its Store is.
+type Cache struct {
+	Store CacheStore
+	// Version is part of the keys, it must change with the states
+	// or the options of the lexers.
+	Version string
+}
+
+// Tokens returns the tokens of src, they are loaded from the store
+// if src was cached, otherwise src is lexed with lex and its tokens are
+// stored, unless lex returns an error. The tokens keep all their fields,
+// an entry which can not be decoded is a miss.
+func (c *Cache) Tokens(src []byte, lex func(src []byte) ([]Token, error)) ([]Token, error) {
+	key := c.Key(src)
+	data, ok, err := c.Store.Load(key)
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		if tokens, err := DecodeTokens(bytes.NewReader(data)); err == nil {
+			return tokens, nil
+		}
+	}
+	tokens, err := lex(src)
+	if err != nil {
+		return tokens, err
+	}
+	var b bytes.Buffer
+	if err := EncodeTokens(&b, tokens); err != nil {
+		return tokens, err
+	}
+	return tokens, c.Store.Store(key, b.Bytes())
+}
+
+// Key returns the key of src, the hex encoded sha256 of the version,
+// the encoding and the content.
+func (c *Cache) Key(src []byte) string {
+	h := sha256.New()
+	h.Write([]byte(c.Version))
+	h.Write([]byte{0})
+	h.Write([]byte(cacheFormat))
+	h.Write([]byte{0})
+	h.Write(src)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// MemoryStore is a CacheStore keeping the entries in memory,
+// it is safe for concurrent use.
+type MemoryStore struct {
+	mu      sync.Mutex
+	entries map[string][]byte
+}
+
+// Load see CacheStore.
+func (s *MemoryStore) Load(key string) ([]byte, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	data, ok := s.entries[key]
+	return data, ok, nil
+}
+
+// Store see CacheStore.
+func (s *MemoryStore) Store(key string, data []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.entries == nil {
+		s.entries = map[string][]byte{}
+	}
+	s.entries[key] = data
+	return nil
+}
+
+// DirStore is a CacheStore keeping each entry in a file of the directory,
+// named after its key. It is safe for concurrent use, including by
+// several processes.
+type DirStore string
+
+// Load see CacheStore.
+func (d DirStore) Load(key string) ([]byte, bool, error) {
+	data, err := os.ReadFile(filepath.Join(string(d), key))
+	if os.IsNotExist(err) {
+		return nil, false, nil
+	}
+	return data, err == nil, err
+}
+
+// Store see CacheStore, the file is written atomically.
+func (d DirStore) Store(key string, data []byte) error {
+	if err := os.MkdirAll(string(d), 0o755); err != nil {
+		return err
+	}
+	f, err := os.CreateTemp(string(d), key+".*")
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(data)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		err = os.Rename(f.Name(), filepath.Join(string(d), key))
+	}
+	if err != nil {
+		os.Remove(f.Name())
+	}
+	return err
+}
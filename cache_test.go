@@ -0,0 +1,72 @@
+package lexer
+
+import (
+	"fmt"
+	"testing"
+)
+
+func Test_Cache(t *testing.T) {
+	rules, _ := NewRules(
+		Rule{Type: IdentToken, Pattern: `\pL+`},
+		Rule{Pattern: `\s+`, Skip: true},
+	)
+	lexed := 0
+	lex := func(src []byte) ([]Token, error) {
+		lexed++
+		return NewBytes(src, rules.State).All()
+	}
+	for _, store := range []CacheStore{&MemoryStore{}, DirStore(t.TempDir())} {
+		lexed = 0
+		c := &Cache{Store: store, Version: "1"}
+		want, _ := lex([]byte("a bc"))
+		lexed = 0
+		for _, src := range []string{"a bc", "a bc", "d", "a bc"} {
+			got, err := c.Tokens([]byte(src), lex)
+			if err != nil {
+				t.Errorf("Expected no error but got %v", err)
+				return
+			}
+			if src == "a bc" && fmt.Sprint(got) != fmt.Sprint(want) {
+				t.Errorf("Expected %v but got %v", want, got)
+				return
+			}
+		}
+		if lexed != 2 {
+			t.Errorf("Expected %v but got %v", 2, lexed)
+			return
+		}
+		c.Version = "2"
+		c.Tokens([]byte("a bc"), lex)
+		if lexed != 3 {
+			t.Errorf("Expected %v but got %v", 3, lexed)
+			return
+		}
+	}
+}
+
+func Test_CacheCorruptEntry(t *testing.T) {
+	lexed := 0
+	lex := func(src []byte) ([]Token, error) {
+		lexed++
+		l := NewBytes(src, numberState)
+		l.Provenance = true
+		return l.All()
+	}
+	store := &MemoryStore{}
+	c := &Cache{Store: store, Version: "1"}
+	want, _ := lex([]byte("12.ab"))
+	for _, data := range [][]byte{[]byte("\x00\x01\x01\x00\x00\xff\xff\xff\xff\x07"), []byte("garbage")} {
+		lexed = 0
+		store.Store(c.Key([]byte("12.ab")), data)
+		got, err := c.Tokens([]byte("12.ab"), lex)
+		if err != nil || lexed != 1 || fmt.Sprint(got) != fmt.Sprint(want) {
+			t.Errorf("Expected %v but got %v %v", want, got, err)
+			return
+		}
+		got, err = c.Tokens([]byte("12.ab"), lex)
+		if err != nil || lexed != 1 || len(got) != len(want) || got[0] != want[0] || got[0].State == "" {
+			t.Errorf("Expected %v but got %v %v", want, got, err)
+			return
+		}
+	}
+}
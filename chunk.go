@@ -0,0 +1,93 @@
+package lexer
+
+import (
+	"errors"
+	"io"
+	"unicode/utf8"
+)
+
+// ErrNeedInput is returned by a ChunkSource when its written data is consumed,
+// and by L.Feed when the lexer is waiting for more input.
+var ErrNeedInput = errors.New("lexer: need more input")
+
+// ChunkSource is a RuneSource fed by successive writes of a non-blocking
+// input, such as a network protocol. See L.Feed.
+type ChunkSource struct {
+	buf    []byte
+	offset int64
+	closed bool
+}
+
+// Write appends p to the unread input.
+func (s *ChunkSource) Write(p []byte) (int, error) {
+	s.buf = append(s.buf, p...)
+	return len(p), nil
+}
+
+// Close signals the end of the input, the source then returns io.EOF
+// rather than ErrNeedInput.
+func (s *ChunkSource) Close() error {
+	s.closed = true
+	return nil
+}
+
+// ReadRune reads the next rune, it returns ErrNeedInput
+// if the rune is not entirely written yet.
+func (s *ChunkSource) ReadRune() (rune, int, error) {
+	if !s.closed && !utf8.FullRune(s.buf) {
+		return 0, 0, ErrNeedInput
+	}
+	if len(s.buf) == 0 {
+		s.buf = nil
+		return 0, 0, io.EOF
+	}
+	r, size := utf8.DecodeRune(s.buf)
+	s.buf = s.buf[size:]
+	s.offset += int64(size)
+	return r, size, nil
+}
+
+// Offset returns the number of bytes read so far.
+func (s *ChunkSource) Offset() int64 {
+	return s.offset
+}
+
+// needInput is the panic value unwinding a state when its source needs more input.
+type needInput struct{}
+
+// Feed Browses the tokens available in the input written so far
+// and invokes f for each of them. It returns ErrNeedInput when the source
+// of l runs out of data in the middle of a state, that state is run again
+// from the start of its current token on the next call, once more data is written.
+// It returns nil, or the lexer error, at the end of the input.
+//
+// States emitting several tokens are resumed from their beginning,
+// they should emit at most one token which may be incomplete.
+func (l *L) Feed(f func(t Token)) error {
+	l.TokenHandler = f
+	for l.startState != nil {
+		if !l.feedOnce() {
+			return ErrNeedInput
+		}
+	}
+	return l.Err
+}
+
+func (l *L) feedOnce() (ok bool) {
+	l.feeding = true
+	defer func() {
+		l.feeding = false
+		if ok {
+			return
+		}
+		if e := recover(); e != nil {
+			if _, need := e.(needInput); !need {
+				panic(e)
+			}
+		}
+		l.position = l.start
+		l.rewind.clear()
+	}()
+	l.startState = l.run(l.startState)
+	return true
+}
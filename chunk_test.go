@@ -0,0 +1,51 @@
+package lexer
+
+import (
+	"fmt"
+	"testing"
+)
+
+func Test_Feed(t *testing.T) {
+	var src ChunkSource
+	l := NewSource(&src, numberState)
+	var values []string
+	f := func(tok Token) {
+		values = append(values, tok.Value)
+	}
+
+	src.Write([]byte("12.a"))
+	if err := l.Feed(f); err != ErrNeedInput {
+		t.Errorf("Expected %v but got %v", ErrNeedInput, err)
+		return
+	}
+	if fmt.Sprint(values) != "[12 .]" {
+		t.Errorf("Expected %v but got %v", "[12 .]", values)
+		return
+	}
+
+	src.Write([]byte("bc 3.d\xc3"))
+	if err := l.Feed(f); err != ErrNeedInput {
+		t.Errorf("Expected %v but got %v", ErrNeedInput, err)
+		return
+	}
+	if fmt.Sprint(values) != "[12 . abc 3 .]" {
+		t.Errorf("Expected %v but got %v", "[12 . abc 3 .]", values)
+		return
+	}
+
+	src.Write([]byte("\xa9"))
+	src.Close()
+	var err error
+	l.ErrorHandler = func(e string) {
+		err = fmt.Errorf("%v", e)
+	}
+	l.Feed(f)
+	if fmt.Sprint(values) != "[12 . abc 3 . d]" {
+		t.Errorf("Expected %v but got %v", "[12 . abc 3 . d]", values)
+		return
+	}
+	if err == nil || err.Error() != `unexpected token 'é'` {
+		t.Errorf("Expected %v but got %v", `unexpected token 'é'`, err)
+		return
+	}
+}
@@ -0,0 +1,73 @@
+package lexer
+
+import (
+	"unicode"
+	"unicode/utf8"
+)
+
+// The precompiled character classes, they must not be modified.
+var (
+	// Digits are the ASCII decimal digits, see MatchDigit.
+	Digits = NewRuneSet("0123456789")
+	// HexDigits are the ASCII hexadecimal digits, see MatchHexDigit.
+	HexDigits = NewRuneSet("0123456789abcdefABCDEF")
+	// Whitespace are the runes with the Unicode White_Space property.
+	Whitespace = new(RuneSet).AddTable(unicode.White_Space)
+	// IDStart are the runes starting an identifier, with the Unicode ID_Start property.
+	IDStart = idStart()
+	// IDContinue are the runes continuing an identifier, with the Unicode ID_Continue property.
+	IDContinue = idContinue()
+)
+
+// idStart returns the runes of UAX #31 ID_Start.
+func idStart() *RuneSet {
+	s := new(RuneSet).AddTable(unicode.L).AddTable(unicode.Nl).AddTable(unicode.Other_ID_Start)
+	return s.removeTable(unicode.Pattern_Syntax).removeTable(unicode.Pattern_White_Space)
+}
+
+// idContinue returns the runes of UAX #31 ID_Continue.
+func idContinue() *RuneSet {
+	s := idStart().AddTable(unicode.Mn).AddTable(unicode.Mc).AddTable(unicode.Nd).
+		AddTable(unicode.Pc).AddTable(unicode.Other_ID_Continue)
+	return s.removeTable(unicode.Pattern_Syntax).removeTable(unicode.Pattern_White_Space)
+}
+
+// removeTable removes the runes of t from s and returns it.
+func (s *RuneSet) removeTable(t *unicode.RangeTable) *RuneSet {
+	for _, r := range t.R16 {
+		for c := rune(r.Lo); c <= rune(r.Hi); c += rune(r.Stride) {
+			s.remove(c)
+		}
+	}
+	for _, r := range t.R32 {
+		for c := rune(r.Lo); c <= rune(r.Hi); c += rune(r.Stride) {
+			s.remove(c)
+		}
+	}
+	return s
+}
+
+// remove removes r from s.
+func (s *RuneSet) remove(r rune) {
+	if r < utf8.RuneSelf {
+		s.ascii[r/64] &^= 1 << (uint(r) % 64)
+		return
+	}
+	for i, rg := range s.ranges {
+		if r < rg.lo || r > rg.hi {
+			continue
+		}
+		switch {
+		case rg.lo == rg.hi:
+			s.ranges = append(s.ranges[:i], s.ranges[i+1:]...)
+		case r == rg.lo:
+			s.ranges[i].lo++
+		case r == rg.hi:
+			s.ranges[i].hi--
+		default:
+			s.ranges = append(s.ranges[:i+1], s.ranges[i:]...)
+			s.ranges[i].hi, s.ranges[i+1].lo = r-1, r+1
+		}
+		return
+	}
+}
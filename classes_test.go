@@ -0,0 +1,31 @@
+package lexer
+
+import "testing"
+
+func Test_Classes(t *testing.T) {
+	for _, c := range []struct {
+		name string
+		set  *RuneSet
+		in   string
+		out  string
+	}{
+		{"Digits", Digits, "0189", "a\u0663"},
+		{"HexDigits", HexDigits, "09afAF", "gG"},
+		{"Whitespace", Whitespace, " \t\n\r\u00a0\u2028\u3000", "a_\u200b"},
+		{"IDStart", IDStart, "aZéλжⅠ℘", "_1$\u0301\u2e2f"},
+		{"IDContinue", IDContinue, "aZ_19\u0301\u0663·", "$-\u2e2f "},
+	} {
+		for _, r := range c.in {
+			if !c.set.Contains(r) {
+				t.Errorf("Expected %q in %v", r, c.name)
+				return
+			}
+		}
+		for _, r := range c.out {
+			if c.set.Contains(r) {
+				t.Errorf("Expected %q not in %v", r, c.name)
+				return
+			}
+		}
+	}
+}
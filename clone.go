@@ -0,0 +1,128 @@
+package lexer
+
+import "weak"
+
+// Clone returns an independent lexer continuing from the position of l,
+// such as to explore an alternative tokenization and discard it.
+// Both lexers read the same input with their own cursor: strings and bytes
+// are shared as is, the other sources are buffered from the first Clone on.
+// The buffered input is released once every lexer still reachable has read
+// past it: a discarded clone holds it until it is garbage collected.
+//
+// The clone keeps the states, the modes and the buffered input of l, not its
+// middlewares, hooks, recorder and TokenHandler: its tokens are read
+// with the pull API, see NextToken.
+func (l *L) Clone() *L {
+	c := *l
+	c.src = l.cloneSource()
+	c.buf = append([]rune(nil), l.buf...)
+	c.value = append([]rune(nil), l.value...)
+	c.widths = append([]runeWidth(nil), l.widths...)
+	c.lineText = append([]rune(nil), l.lineText...)
+	c.modes = append([]string(nil), l.modes...)
+	c.chained = append([][]StateFunc(nil), l.chained...)
+	c.pending = append([]Token(nil), l.pending...)
+	c.diagnostics = append(Diagnostics(nil), l.diagnostics...)
+	c.includes = append([]included(nil), l.includes...)
+	c.rewind = runeStack{runes: append([]rune(nil), l.rewind.runes...)}
+	c.wrapped, c.hooks, c.recorder, c.delims, c.lazy = wrapped{}, hooks{}, nil, nil, nil
+	c.transitions, c.transactions = nil, nil
+	c.TokenHandler = nil
+	if c.hasNext {
+		c.TokenHandler = c.queue
+	}
+	return &c
+}
+
+// cursorSource is implemented by the sources which can be read
+// with several cursors, see L.Clone.
+type cursorSource interface {
+	// cursor returns a source reading the same input from the same offset.
+	cursor() RuneSource
+}
+
+// cloneSource returns a cursor of the source of l, reading independently.
+func (l *L) cloneSource() RuneSource {
+	if s, ok := l.src.(cursorSource); ok {
+		return s.cursor()
+	}
+	in := &sharedInput{src: l.src}
+	c := &sharedCursor{in: in, offset: l.src.Offset()}
+	in.cursors = append(in.cursors, weak.Make(c))
+	l.src = c
+	return c.cursor()
+}
+
+// sharedInput buffers a source read by several cursors,
+// from the position of the slowest cursor.
+type sharedInput struct {
+	src     RuneSource
+	base    int // position in the input of runes[0]
+	runes   []rune
+	sizes   []int
+	cursors []weak.Pointer[sharedCursor]
+	trimAt  int // length of runes triggering the next trim
+}
+
+// push buffers the rune r read from src, of size bytes.
+func (in *sharedInput) push(r rune, size int) {
+	if len(in.runes) >= in.trimAt {
+		in.trim()
+	}
+	in.runes = append(in.runes, r)
+	in.sizes = append(in.sizes, size)
+}
+
+// trim drops the runes read by all the live cursors.
+func (in *sharedInput) trim() {
+	min := in.base + len(in.runes)
+	live := in.cursors[:0]
+	for _, w := range in.cursors {
+		if c := w.Value(); c != nil {
+			live = append(live, w)
+			if c.pos < min {
+				min = c.pos
+			}
+		}
+	}
+	clear(in.cursors[len(live):])
+	in.cursors = live
+	if n := min - in.base; n > 0 {
+		in.runes = in.runes[:copy(in.runes, in.runes[n:])]
+		in.sizes = in.sizes[:copy(in.sizes, in.sizes[n:])]
+		in.base = min
+	}
+	in.trimAt = 2*len(in.runes) + 256
+}
+
+// sharedCursor reads a sharedInput.
+type sharedCursor struct {
+	in     *sharedInput
+	pos    int // position in the input
+	offset int64
+}
+
+func (c *sharedCursor) ReadRune() (rune, int, error) {
+	if c.pos == c.in.base+len(c.in.runes) {
+		r, size, err := c.in.src.ReadRune()
+		if err != nil || size == 0 {
+			return r, size, err
+		}
+		c.in.push(r, size)
+	}
+	i := c.pos - c.in.base
+	r, size := c.in.runes[i], c.in.sizes[i]
+	c.pos++
+	c.offset += int64(size)
+	return r, size, nil
+}
+
+func (c *sharedCursor) Offset() int64 {
+	return c.offset
+}
+
+func (c *sharedCursor) cursor() RuneSource {
+	d := *c
+	c.in.cursors = append(c.in.cursors, weak.Make(&d))
+	return &d
+}
@@ -0,0 +1,65 @@
+package lexer
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func Test_Clone(t *testing.T) {
+	rules, _ := NewRules(
+		Rule{Type: OpToken, Pattern: `>>?`},
+		Rule{Type: IdentToken, Pattern: `[a-z]+`},
+		Rule{Pattern: `\s+`, Skip: true},
+	)
+	split, _ := NewRules(
+		Rule{Type: OpToken, Pattern: `>`},
+		Rule{Type: IdentToken, Pattern: `[a-z]+`},
+		Rule{Pattern: `\s+`, Skip: true},
+	)
+	for _, l := range []*L{
+		NewString("a >> b c", rules.State),
+		New(strings.NewReader("a >> b c"), rules.State),
+	} {
+		l.NextToken()
+		c := l.Clone()
+		c.nextState = split.State
+		var got []string
+		for i := 0; i < 3; i++ {
+			tok := c.NextToken()
+			got = append(got, fmt.Sprintf("%v@%v", tok.Value, tok.Offset))
+		}
+		for tok := l.NextToken(); tok != nil; tok = l.NextToken() {
+			got = append(got, fmt.Sprintf("%v@%v", tok.Value, tok.Offset))
+		}
+		expected := "[>@2 >@3 b@5 >>@2 b@5 c@7]"
+		if fmt.Sprint(got) != expected {
+			t.Errorf("Expected %v but got %v", expected, got)
+			return
+		}
+	}
+}
+
+func Test_CloneReleasesInput(t *testing.T) {
+	l := New(iotest.OneByteReader(strings.NewReader(strings.Repeat("a", 100000))), nil)
+	c := l.Clone()
+	in := l.src.(*sharedCursor).in
+	for i := 0; i < 1000; i++ {
+		l.Next()
+	}
+	if c.Next() != 'a' || len(in.runes) != 1000 {
+		t.Errorf("Expected %v buffered runes but got %v", 1000, len(in.runes))
+		return
+	}
+	c = nil
+	runtime.GC()
+	for l.Next() != EOFRune {
+		l.Ignore()
+	}
+	if len(in.runes) > 1000 {
+		t.Errorf("Expected at most %v buffered runes but got %v", 1000, len(in.runes))
+		return
+	}
+}
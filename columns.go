@@ -0,0 +1,44 @@
+package lexer
+
+import (
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// ColumnUnit is the unit of the columns of the tokens and of the errors.
+type ColumnUnit int
+
+const (
+	// ColumnRunes counts the columns in runes.
+	ColumnRunes ColumnUnit = iota
+	// ColumnBytes counts the columns in bytes of the source.
+	ColumnBytes
+	// ColumnUTF16 counts the columns in UTF-16 code units, as LSP does.
+	ColumnUTF16
+)
+
+// colWidth returns the width of the rune r at index i of buf, in l.Columns.
+func (l *L) colWidth(i int, r rune) int {
+	switch l.Columns {
+	case ColumnBytes:
+		return l.runeSize(i, r)
+	case ColumnUTF16:
+		return utf16.RuneLen(r)
+	}
+	return 1
+}
+
+// width returns the number of columns of s, in u.
+func (u ColumnUnit) width(s string) int {
+	switch u {
+	case ColumnBytes:
+		return len(s)
+	case ColumnUTF16:
+		n := 0
+		for _, r := range s {
+			n += utf16.RuneLen(r)
+		}
+		return n
+	}
+	return utf8.RuneCountInString(s)
+}
@@ -0,0 +1,63 @@
+package lexer
+
+import "strings"
+
+// Chain returns a state running states in sequence: when a state of the
+// chain returns nil, the lexing continues with the following one, rather
+// than ending. The chain ends after its last state, or when a state
+// reports an error and returns nil.
+func Chain(states ...StateFunc) StateFunc {
+	if len(states) == 0 {
+		return nil
+	}
+	return func(l *L) StateFunc {
+		l.dropChained()
+		l.chained = append(l.chained, states[1:])
+		return states[0]
+	}
+}
+
+// dropChained removes the exhausted chains, whose last state is running,
+// so a chain looping back into a chain does not grow the stack.
+func (l *L) dropChained() {
+	n := len(l.chained)
+	for n > 0 && len(l.chained[n-1]) == 0 {
+		n--
+	}
+	l.chained = l.chained[:n]
+}
+
+// chainNext returns the state following the ended state of the innermost
+// running chain, all the chains end if it failed.
+func (l *L) chainNext(failed bool) StateFunc {
+	if failed {
+		l.chained = l.chained[:0]
+	}
+	for n := len(l.chained); n > 0; n = len(l.chained) {
+		if states := l.chained[n-1]; len(states) > 0 {
+			l.chained[n-1] = states[1:]
+			return states[0]
+		}
+		l.chained = l.chained[:n-1]
+	}
+	return nil
+}
+
+// If returns a state continuing with then if pred reports true, with
+// otherwise if not. pred must leave the input as it found it, see PeekIn.
+// Either state may be nil to end the lexing.
+func If(pred func(l *L) bool, then, otherwise StateFunc) StateFunc {
+	return func(l *L) StateFunc {
+		if pred(l) {
+			return then
+		}
+		return otherwise
+	}
+}
+
+// PeekIn returns a predicate reporting whether the next rune is one of chars.
+func PeekIn(chars string) func(l *L) bool {
+	return func(l *L) bool {
+		return strings.ContainsRune(chars, l.Peek())
+	}
+}
@@ -0,0 +1,118 @@
+package lexer
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func Test_Chain(t *testing.T) {
+	header := func(l *L) StateFunc {
+		l.AcceptString("v1")
+		l.Emit(IdentToken)
+		return nil
+	}
+	sep := func(l *L) StateFunc {
+		if !l.AcceptString("\n") {
+			l.Error("expected a newline")
+			return nil
+		}
+		l.Ignore()
+		return nil
+	}
+	var body StateFunc
+	body = func(l *L) StateFunc {
+		if l.Peek() == EOFRune {
+			return nil
+		}
+		l.Next()
+		l.Emit(OpToken)
+		return body
+	}
+	for _, c := range []struct {
+		src, expected, err string
+	}{
+		{"v1\nab", "[2:v1 1:a 1:b]", "<nil>"},
+		{"v1ab", "[2:v1]", "expected a newline"},
+	} {
+		l := NewString(c.src, Chain(header, sep, body))
+		l.ErrorHandler = func(string) {}
+		var got []string
+		l.Scan(func(tok Token) {
+			got = append(got, fmt.Sprintf("%v:%v", tok.Type, tok.Value))
+		})
+		if fmt.Sprint(got) != c.expected || fmt.Sprint(l.Err) != c.err {
+			t.Errorf("Expected %v %v but got %v %v", c.expected, c.err, got, l.Err)
+			return
+		}
+	}
+	if Chain() != nil {
+		t.Errorf("Expected %v but got %v", "nil", "a state")
+		return
+	}
+}
+
+func chainWord(l *L) StateFunc {
+	l.Take("abcdefghijklmnopqrstuvwxyz")
+	l.Emit(IdentToken)
+	return nil
+}
+
+func chainSpace(l *L) StateFunc {
+	l.Take(" ")
+	l.Ignore()
+	return nil
+}
+
+func Test_ChainNested(t *testing.T) {
+	word := Chain(chainWord, chainSpace)
+	l := NewString("ab cd ef", Chain(word, Chain(word, word)), WithProvenance())
+	var got []string
+	err := l.Scan(func(tok Token) {
+		got = append(got, tok.Value+":"+tok.State[strings.LastIndexByte(tok.State, '.'):])
+	})
+	expected := "[ab:.chainWord cd:.chainWord ef:.chainWord]"
+	if err != nil || fmt.Sprint(got) != expected {
+		t.Errorf("Expected %v but got %v %v", expected, got, err)
+		return
+	}
+}
+
+func Test_ChainLoop(t *testing.T) {
+	var loop StateFunc
+	again := func(l *L) StateFunc {
+		if l.Peek() == EOFRune {
+			return nil
+		}
+		return loop
+	}
+	loop = Chain(chainWord, chainSpace, again)
+	l := NewString(strings.Repeat("ab ", 100), loop)
+	depth := 0
+	l.OnEmit(func(Token) {
+		depth = max(depth, len(l.chained))
+	})
+	tokens, err := l.All()
+	if err != nil || len(tokens) != 100 || depth != 1 {
+		t.Errorf("Expected %v tokens at depth %v but got %v at depth %v %v", 100, 1, len(tokens), depth, err)
+		return
+	}
+}
+
+func Test_If(t *testing.T) {
+	var value StateFunc
+	again := func(l *L) StateFunc { return value(l) }
+	value = WhitespaceState(If(PeekIn(`"'`),
+		If(PeekIn(`"`), QuotedStringState('"', IdentToken, again), QuotedStringState('\'', IdentToken, again)),
+		NumberState(NumberToken, again)))
+	l := NewString(`1 "a" 'b' 2`, value)
+	var got []string
+	err := l.Scan(func(tok Token) {
+		got = append(got, fmt.Sprintf("%v:%v", tok.Type, tok.Value))
+	})
+	expected := `[0:1 2:"a" 2:'b' 0:2]`
+	if err != nil || fmt.Sprint(got) != expected {
+		t.Errorf("Expected %v but got %v %v", expected, got, err)
+		return
+	}
+}
@@ -0,0 +1,87 @@
+package lexer
+
+import (
+	"errors"
+	"fmt"
+	"io"
+)
+
+// DefaultReadBuffer is the size in bytes of the read buffer of NewWithConfig,
+// when Config.ReadBuffer is zero.
+const DefaultReadBuffer = 4096
+
+// Config describes a lexer, it is an alternative to the options of New
+// which can be validated before the lexer is created, see NewWithConfig.
+type Config struct {
+	Src   io.Reader // the source, required
+	Start StateFunc // the initial state, required
+	// ReadBuffer is the size in bytes of the read buffer of Src,
+	// DefaultReadBuffer if zero, see WithReadBuffer.
+	ReadBuffer        int
+	MaxBuffer         int        // see L.MaxBuffer
+	MaxErrors         int        // see L.MaxErrors, it requires ErrorHandler
+	Columns           ColumnUnit // see L.Columns
+	BaseOffset        int        // see L.SetBaseOffset
+	NormalizeNewlines bool       // see L.NormalizeNewlines
+	ErrorHandler      func(e string)
+	WarningHandler    func(e *LexError)
+	Middlewares       []Middleware // see Wrap
+	Options           []Option     // applied after the other settings
+}
+
+// Validate reports the first missing, invalid or contradictory setting of c.
+func (c Config) Validate() error {
+	switch {
+	case c.Src == nil:
+		return errors.New("config has no source")
+	case c.Start == nil:
+		return errors.New("config has no initial state")
+	case c.ReadBuffer < 0:
+		return fmt.Errorf("invalid read buffer size %v", c.ReadBuffer)
+	case c.MaxBuffer < 0:
+		return fmt.Errorf("invalid max buffer %v", c.MaxBuffer)
+	case c.MaxErrors < 0:
+		return fmt.Errorf("invalid max errors %v", c.MaxErrors)
+	case c.MaxErrors > 0 && c.ErrorHandler == nil:
+		return errors.New("max errors requires an error handler, the first error panics without it")
+	case c.Columns < ColumnRunes || c.Columns > ColumnUTF16:
+		return fmt.Errorf("invalid column unit %v", c.Columns)
+	case c.BaseOffset < 0:
+		return fmt.Errorf("invalid base offset %v", c.BaseOffset)
+	}
+	return nil
+}
+
+// options returns the options equivalent to c.
+func (c Config) options() []Option {
+	size := c.ReadBuffer
+	if size == 0 {
+		size = DefaultReadBuffer
+	}
+	opts := []Option{
+		WithReadBuffer(size),
+		WithMaxBuffer(c.MaxBuffer),
+		WithMaxErrors(c.MaxErrors),
+		WithColumns(c.Columns),
+		WithErrorHandler(c.ErrorHandler),
+		WithWarningHandler(c.WarningHandler),
+	}
+	if c.BaseOffset != 0 {
+		opts = append(opts, WithBaseOffset(c.BaseOffset))
+	}
+	if c.NormalizeNewlines {
+		opts = append(opts, WithNormalizedNewlines())
+	}
+	if len(c.Middlewares) > 0 {
+		opts = append(opts, WithMiddleware(c.Middlewares...))
+	}
+	return append(opts, c.Options...)
+}
+
+// NewWithConfig validates cfg and creates a lexer ready to parse cfg.Src.
+func NewWithConfig(cfg Config) (*L, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return New(cfg.Src, cfg.Start, cfg.options()...), nil
+}
@@ -0,0 +1,56 @@
+package lexer
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func Test_NewWithConfig(t *testing.T) {
+	var errs []string
+	l, err := NewWithConfig(Config{
+		Src:               strings.NewReader("12.ab\r\n3.x#"),
+		Start:             numberState,
+		BaseOffset:        10,
+		NormalizeNewlines: true,
+		MaxErrors:         1,
+		ErrorHandler:      func(e string) { errs = append(errs, e) },
+	})
+	if err != nil {
+		t.Errorf("Expected no error but got %v", err)
+		return
+	}
+	var got []string
+	l.Scan(func(tok Token) {
+		got = append(got, fmt.Sprintf("%v@%v", tok.Value, tok.Offset))
+	})
+	expected := "[12@10 .@12 ab@13 3@17 .@18 x@19]"
+	if fmt.Sprint(got) != expected || fmt.Sprint(errs) != "[unexpected token '#' too many errors]" {
+		t.Errorf("Expected %v but got %v %v", expected, got, errs)
+		return
+	}
+}
+
+func Test_ConfigValidate(t *testing.T) {
+	src := strings.NewReader("")
+	for _, c := range []struct {
+		cfg      Config
+		expected string
+	}{
+		{Config{Start: numberState}, "config has no source"},
+		{Config{Src: src}, "config has no initial state"},
+		{Config{Src: src, Start: numberState, ReadBuffer: -1}, "invalid read buffer size -1"},
+		{Config{Src: src, Start: numberState, MaxErrors: 3}, "max errors requires an error handler, the first error panics without it"},
+		{Config{Src: src, Start: numberState, Columns: 7}, "invalid column unit 7"},
+		{Config{Src: src, Start: numberState}, "<nil>"},
+	} {
+		if err := c.cfg.Validate(); fmt.Sprint(err) != c.expected {
+			t.Errorf("Expected %v but got %v", c.expected, err)
+			return
+		}
+	}
+	if l, err := NewWithConfig(Config{}); l != nil || err == nil {
+		t.Errorf("Expected %v but got %v %v", "config has no source", l, err)
+		return
+	}
+}
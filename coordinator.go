@@ -0,0 +1,171 @@
+package lexer
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"math"
+	"slices"
+)
+
+// Coordinator lexes a large input by splitting it into ranges lexed
+// concurrently, it delivers their tokens in order, with globally correct
+// positions, as the ranges complete.
+//
+// Each range is lexed by a new lexer of the grammar, in its default mode.
+// When the previous range ends in another mode, such as inside a string,
+// the range is lexed again resuming that mode before its delivery.
+// The ranges must still start on token boundaries, see Boundary.
+type Coordinator struct {
+	Grammar *Grammar
+	// Workers is the number of ranges lexed or held at once, 1 if zero.
+	Workers int
+	// Size is the approximate size of a range, 1MiB if zero.
+	Size int64
+	// Boundary returns the start of the first range after off, at which the
+	// lexing can resume. It defaults to LineBoundary.
+	Boundary func(r io.ReaderAt, off int64) int64
+}
+
+// LineBoundary returns the offset of the line following off in r,
+// or the end of r.
+func LineBoundary(r io.ReaderAt, off int64) int64 {
+	br := bufio.NewReader(io.NewSectionReader(r, off, math.MaxInt64-off))
+	for {
+		b, err := br.ReadByte()
+		if err != nil {
+			return off
+		}
+		off++
+		if b == '\n' {
+			return off
+		}
+	}
+}
+
+// span is a range of the input and its lexing result.
+type span struct {
+	off, size int64
+	modes     []string // the modes at the start of the range
+	end       []string // the modes at the end of the range
+	lines     int      // number of newlines in the range
+	tokens    []Token
+	err       error
+	done      chan struct{}
+}
+
+// Ranges splits the size bytes of r into the ranges lexed by Lex,
+// it returns their start offsets. It fails if Boundary does not advance.
+func (c *Coordinator) Ranges(r io.ReaderAt, size int64) ([]int64, error) {
+	step := c.Size
+	if step <= 0 {
+		step = 1 << 20
+	}
+	boundary := c.Boundary
+	if boundary == nil {
+		boundary = LineBoundary
+	}
+	starts := []int64{0}
+	for off := int64(0); ; {
+		next := boundary(r, off+step)
+		if next >= size {
+			return starts, nil
+		}
+		if next <= off {
+			return nil, fmt.Errorf("boundary %v does not advance past %v", next, off)
+		}
+		off = next
+		starts = append(starts, off)
+	}
+}
+
+// Lex lexes the size bytes of r and invokes f for each token, in order.
+// It returns the first lexer error, the tokens following it are dropped.
+func (c *Coordinator) Lex(r io.ReaderAt, size int64, f func(t Token)) error {
+	starts, err := c.Ranges(r, size)
+	if err != nil {
+		return err
+	}
+	spans := make([]*span, len(starts))
+	for i, off := range starts {
+		end := size
+		if i+1 < len(starts) {
+			end = starts[i+1]
+		}
+		spans[i] = &span{off: off, size: end - off, done: make(chan struct{})}
+	}
+
+	workers := c.Workers
+	if workers <= 0 {
+		workers = 1
+	}
+	// a slot is released once its span is delivered,
+	// so at most workers spans are held at once.
+	slots := make(chan struct{}, workers)
+	stop := make(chan struct{})
+	defer close(stop)
+	go func() {
+		for _, s := range spans {
+			select {
+			case slots <- struct{}{}:
+			case <-stop:
+				return
+			}
+			go func(s *span) {
+				c.lexSpan(r, s, nil)
+				close(s.done)
+			}(s)
+		}
+	}()
+
+	var modes []string
+	lines, index := 0, 0
+	for _, s := range spans {
+		<-s.done
+		if !slices.Equal(s.modes, modes) {
+			c.lexSpan(r, s, modes)
+		}
+		for _, t := range s.tokens {
+			t.Line += lines
+			t.Index += index
+			f(t)
+		}
+		index += len(s.tokens)
+		if s.err != nil {
+			errs := []error{s.err}
+			if j, ok := s.err.(interface{ Unwrap() []error }); ok {
+				errs = j.Unwrap()
+			}
+			for _, err := range errs {
+				if e, ok := err.(*LexError); ok {
+					e.Line += lines
+					e.EndLine += lines
+				}
+			}
+			return s.err
+		}
+		lines += s.lines
+		modes = s.end
+		s.tokens = nil
+		<-slots
+	}
+	return nil
+}
+
+// lexSpan lexes s, starting in modes.
+func (c *Coordinator) lexSpan(r io.ReaderAt, s *span, modes []string) {
+	b := make([]byte, s.size)
+	if _, err := r.ReadAt(b, s.off); err != nil && err != io.EOF {
+		s.err = err
+		return
+	}
+	s.lines = bytes.Count(b, []byte{'\n'})
+	l := c.Grammar.New(bytes.NewReader(b))
+	l.SetBaseOffset(int(s.off))
+	l.ErrorHandler = func(string) {}
+	l.modes = append([]string(nil), modes...)
+	s.modes = modes
+	s.tokens, s.err = l.All()
+	s.end = l.modes
+}
@@ -0,0 +1,97 @@
+package lexer
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+)
+
+func Test_Coordinator(t *testing.T) {
+	spec, err := LoadSpec(strings.NewReader(`{
+		"name": "words",
+		"tokens": [
+			{"name": "word", "pattern": "[a-z]+"},
+			{"pattern": "\\s+", "skip": true}
+		]
+	}`))
+	if err != nil {
+		t.Errorf("Unexpected error %v", err)
+		return
+	}
+	g := NewGrammarSet().Set("words", spec)
+	src := "ab cd\nef\n\ngh ij\nkl"
+
+	c := &Coordinator{Grammar: g, Workers: 2, Size: 4}
+	if got := fmt.Sprint(c.Ranges(strings.NewReader(src), int64(len(src)))); got != "[0 6 16] <nil>" {
+		t.Errorf("Expected %v but got %v", "[0 6 16] <nil>", got)
+		return
+	}
+	var tokens []Token
+	err = c.Lex(strings.NewReader(src), int64(len(src)), func(tok Token) {
+		tokens = append(tokens, tok)
+	})
+	if err != nil {
+		t.Errorf("Unexpected error %v", err)
+		return
+	}
+	expected, _ := g.New(strings.NewReader(src)).All()
+	if fmt.Sprint(tokens) != fmt.Sprint(expected) {
+		t.Errorf("Expected %v but got %v", expected, tokens)
+		return
+	}
+
+	src = "ab\ncd\nE"
+	err = c.Lex(strings.NewReader(src), int64(len(src)), func(Token) {})
+	var e *LexError
+	if !errors.As(err, &e) || e.Line != 3 {
+		t.Errorf("Expected an error on line 3 but got %v", err)
+		return
+	}
+}
+
+func Test_CoordinatorModes(t *testing.T) {
+	spec, err := LoadSpec(strings.NewReader(`{
+		"name": "strings",
+		"tokens": [
+			{"name": "word", "pattern": "[a-z]+"},
+			{"name": "quote", "pattern": "\"", "push": "string"},
+			{"name": "quote", "pattern": "\"", "modes": ["string"], "pop": true},
+			{"name": "text", "pattern": "[^\"\\n]+", "modes": ["string"]},
+			{"pattern": "\\n", "modes": ["string"], "skip": true},
+			{"pattern": "\\s+", "skip": true}
+		]
+	}`))
+	if err != nil {
+		t.Errorf("Unexpected error %v", err)
+		return
+	}
+	g := NewGrammarSet().Set("strings", spec)
+	src := "ab \"cd\nef gh\nij\" kl\nmn"
+	expected, _ := g.New(strings.NewReader(src)).All()
+	for _, workers := range []int{1, 3} {
+		c := &Coordinator{Grammar: g, Workers: workers, Size: 2}
+		var tokens []Token
+		err = c.Lex(strings.NewReader(src), int64(len(src)), func(tok Token) {
+			tokens = append(tokens, tok)
+		})
+		if err != nil || fmt.Sprint(tokens) != fmt.Sprint(expected) {
+			t.Errorf("Expected %v but got %v %v", expected, tokens, err)
+			return
+		}
+	}
+}
+
+func Test_CoordinatorBoundary(t *testing.T) {
+	c := &Coordinator{Size: 4, Boundary: func(r io.ReaderAt, off int64) int64 { return 2 }}
+	src := strings.NewReader("ab cd ef gh")
+	if _, err := c.Ranges(src, src.Size()); fmt.Sprint(err) != "boundary 2 does not advance past 2" {
+		t.Errorf("Expected %v but got %v", "boundary 2 does not advance past 2", err)
+		return
+	}
+	if err := c.Lex(src, src.Size(), func(Token) {}); err == nil {
+		t.Errorf("Expected %v but got %v", "an error", err)
+		return
+	}
+}
@@ -0,0 +1,123 @@
+package lexer
+
+import "fmt"
+
+// DelimKind identifies a pair of delimiters, such as parentheses or brackets.
+type DelimKind int
+
+// DelimPair defines the opening and closing tokens of a kind of delimiters,
+// they are matched by Type, and by Value if it is set.
+type DelimPair struct {
+	Kind        DelimKind
+	Open, Close Token
+}
+
+// DelimTracker checks the delimiters emitted by a lexer, see L.TrackDelims.
+type DelimTracker struct {
+	Pairs []DelimPair
+	// Recover inserts the closing tokens of the delimiters left open,
+	// before a mismatched closer and at the end of the input.
+	Recover bool
+}
+
+// delims is the state of the DelimTracker of a lexer.
+type delims struct {
+	DelimTracker
+	open []openDelim
+}
+
+// openDelim is an opening token waiting for its closer.
+type openDelim struct {
+	pair *DelimPair
+	tok  Token
+}
+
+// TrackDelims checks the delimiters of the emitted tokens with t,
+// it reports the unbalanced and mismatched delimiters with Error.
+// The messages give the position of the opening delimiter,
+// the errors are located at the offending closer or at the end of the input.
+// The delimiters are checked before they are emitted, so the recovery
+// tokens precede the closer in the Index order, see Wrap.
+func (l *L) TrackDelims(t DelimTracker) {
+	d := &delims{DelimTracker: t}
+	l.delims = d
+	Wrap(l, Middleware{Emit: func(t TokenType, value string, next func(t TokenType, value string)) {
+		d.handle(l, Token{Type: t, Value: value, Line: l.line, Col: l.col})
+		next(t, value)
+	}})
+	l.OnEnd(func() {
+		d.end(l)
+	})
+}
+
+// Depth returns the number of delimiters of kind opened and not closed yet,
+// it is 0 if the delimiters are not tracked, see TrackDelims.
+func (l *L) Depth(kind DelimKind) int {
+	if l.delims == nil {
+		return 0
+	}
+	n := 0
+	for _, o := range l.delims.open {
+		if o.pair.Kind == kind {
+			n++
+		}
+	}
+	return n
+}
+
+func matchToken(tmpl, t Token) bool {
+	return t.Type == tmpl.Type && (tmpl.Value == "" || t.Value == tmpl.Value)
+}
+
+func (d *delims) handle(l *L, tok Token) {
+	for i := range d.Pairs {
+		p := &d.Pairs[i]
+		if matchToken(p.Open, tok) {
+			d.open = append(d.open, openDelim{pair: p, tok: tok})
+			return
+		}
+		if matchToken(p.Close, tok) {
+			d.close(l, p, tok)
+			return
+		}
+	}
+}
+
+func (d *delims) close(l *L, p *DelimPair, tok Token) {
+	i := len(d.open) - 1
+	for i >= 0 && d.open[i].pair != p {
+		i--
+	}
+	if i < 0 {
+		l.Error(fmt.Sprintf("unexpected %q", tok.Value))
+		return
+	}
+	if i == len(d.open)-1 {
+		d.open = d.open[:i]
+		return
+	}
+	top := d.open[len(d.open)-1]
+	l.Error(fmt.Sprintf("%q does not close %q opened at %v:%v",
+		tok.Value, top.tok.Value, top.tok.Line, top.tok.Col))
+	for len(d.open)-1 > i {
+		d.pop(l)
+	}
+	d.open = d.open[:i]
+}
+
+// pop removes the innermost open delimiter, it inserts its closer if Recover is set.
+func (d *delims) pop(l *L) {
+	top := d.open[len(d.open)-1]
+	d.open = d.open[:len(d.open)-1]
+	if d.Recover {
+		l.InsertToken(top.pair.Close)
+	}
+}
+
+func (d *delims) end(l *L) {
+	for len(d.open) > 0 {
+		top := d.open[len(d.open)-1]
+		l.Error(fmt.Sprintf("unclosed %q opened at %v:%v", top.tok.Value, top.tok.Line, top.tok.Col))
+		d.pop(l)
+	}
+}
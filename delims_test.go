@@ -0,0 +1,87 @@
+package lexer
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func delimsLexer(src string, recover bool) (*L, *[]string) {
+	rules, _ := NewRules(
+		Rule{Type: IdentToken, Pattern: `[a-z]+`},
+		Rule{Type: OpToken, Pattern: `[()\[\]]`},
+		Rule{Pattern: `\s+`, Skip: true},
+	)
+	l := New(strings.NewReader(src), rules.State)
+	l.TrackDelims(DelimTracker{
+		Pairs: []DelimPair{
+			{Kind: 1, Open: Token{Type: OpToken, Value: "("}, Close: Token{Type: OpToken, Value: ")"}},
+			{Kind: 2, Open: Token{Type: OpToken, Value: "["}, Close: Token{Type: OpToken, Value: "]"}},
+		},
+		Recover: recover,
+	})
+	errs := &[]string{}
+	l.OnError(func(e *LexError) {
+		*errs = append(*errs, fmt.Sprintf("%v:%v %v", e.Line, e.Col, e.Msg))
+	})
+	l.ErrorHandler = func(string) {}
+	return l, errs
+}
+
+func Test_TrackDelims(t *testing.T) {
+	l, errs := delimsLexer("[a (b]\n) (c", false)
+	l.All()
+	expected := `[1:6 "]" does not close "(" opened at 1:4 2:1 unexpected ")" 2:5 unclosed "(" opened at 2:3]`
+	if fmt.Sprint(*errs) != expected {
+		t.Errorf("Expected %v but got %v", expected, *errs)
+		return
+	}
+}
+
+func Test_TrackDelimsRecover(t *testing.T) {
+	l, errs := delimsLexer("[a (b] [c", true)
+	var values []string
+	l.Scan(func(tok Token) {
+		values = append(values, fmt.Sprintf("%v#%v", tok.Value, tok.Index))
+	})
+	expected := "[[#0 a#1 (#2 b#3 )#4 ]#5 [#6 c#7 ]#8]"
+	if fmt.Sprint(values) != expected {
+		t.Errorf("Expected %v but got %v", expected, values)
+		return
+	}
+	if len(*errs) != 2 {
+		t.Errorf("Expected 2 errors but got %v", *errs)
+		return
+	}
+}
+
+func Test_TrackDelimsMaxErrors(t *testing.T) {
+	l, _ := delimsLexer("(a ] b c", true)
+	l.MaxErrors = 1
+	var values []string
+	l.Scan(func(tok Token) {
+		values = append(values, tok.Value)
+	})
+	expected := "[( a ] )]"
+	if fmt.Sprint(values) != expected {
+		t.Errorf("Expected %v but got %v", expected, values)
+		return
+	}
+}
+
+func Test_Depth(t *testing.T) {
+	l, _ := delimsLexer("a [b (c)] d", false)
+	var depths []string
+	l.Scan(func(tok Token) {
+		depths = append(depths, fmt.Sprintf("%v%v%v", tok.Value, l.Depth(1), l.Depth(2)))
+	})
+	expected := "[a00 [01 b01 (11 c11 )01 ]00 d00]"
+	if fmt.Sprint(depths) != expected {
+		t.Errorf("Expected %v but got %v", expected, depths)
+		return
+	}
+	if New(strings.NewReader(""), nil).Depth(1) != 0 {
+		t.Error("Expected a 0 depth")
+		return
+	}
+}
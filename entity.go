@@ -0,0 +1,51 @@
+package lexer
+
+import (
+	"html"
+	"unicode"
+	"unicode/utf8"
+)
+
+// maxEntityName is the length of the longest HTML entity name.
+const maxEntityName = 32
+
+// ScanEntity consumes the HTML or XML character reference at the current
+// position and returns its rune: a named entity such as &amp;, or a numeric
+// reference such as &#39; or &#x27;. The references must end with ';'.
+// If there is no valid reference nothing is consumed and ok is false,
+// so are the named entities of several runes.
+func ScanEntity(l *L) (r rune, ok bool) {
+	if l.Peek() != '&' {
+		return 0, false
+	}
+	mark := l.position
+	l.Next()
+	if l.Peek() == '#' {
+		l.Next()
+		radix := 10
+		if l.Peek() == 'x' || l.Peek() == 'X' {
+			l.Next()
+			radix = 16
+		}
+		n := 0
+		for ; digitValue(l.Peek()) < radix && r <= unicode.MaxRune; n++ {
+			r = r*rune(radix) + rune(digitValue(l.Next()))
+		}
+		if n > 0 && l.Next() == ';' && r > 0 && r <= unicode.MaxRune && utf8.ValidRune(r) {
+			return r, true
+		}
+	} else {
+		for n := 0; n < maxEntityName && (unicode.IsLetter(l.Peek()) || unicode.IsDigit(l.Peek())); n++ {
+			l.Next()
+		}
+		if l.Next() == ';' {
+			name := string(l.buf[mark:l.position])
+			if v := html.UnescapeString(name); v != name && utf8.RuneCountInString(v) == 1 {
+				r, _ = utf8.DecodeRuneInString(v)
+				return r, true
+			}
+		}
+	}
+	l.rewindTo(mark)
+	return 0, false
+}
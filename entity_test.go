@@ -0,0 +1,37 @@
+package lexer
+
+import (
+	"fmt"
+	"testing"
+)
+
+func Test_ScanEntity(t *testing.T) {
+	for _, c := range []struct {
+		src   string
+		r     rune
+		ok    bool
+		value string
+	}{
+		{"&amp;x", '&', true, "&amp;"},
+		{"&apos;", '\'', true, "&apos;"},
+		{"&eacute;", 'é', true, "&eacute;"},
+		{"&#39;", '\'', true, "&#39;"},
+		{"&#x1F600;", '😀', true, "&#x1F600;"},
+		{"&amp", 0, false, ""},
+		{"&nope;", 0, false, ""},
+		{"&#xD800;", 0, false, ""},
+		{"&#99999999999;", 0, false, ""},
+		{"&#;", 0, false, ""},
+		{"&NotEqualTilde;", 0, false, ""},
+		{"a", 0, false, ""},
+	} {
+		l := NewString(c.src, nil)
+		r, ok := ScanEntity(l)
+		got := fmt.Sprintf("%q %v %q", r, ok, l.Current())
+		expected := fmt.Sprintf("%q %v %q", c.r, c.ok, c.value)
+		if got != expected {
+			t.Errorf("Expected %v but got %v", expected, got)
+			return
+		}
+	}
+}
@@ -0,0 +1,287 @@
+package lexer
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// Severity of a LexError.
+type Severity int
+
+const (
+	SeverityError Severity = iota
+	SeverityWarning
+	SeverityInfo
+)
+
+func (s Severity) String() string {
+	switch s {
+	case SeverityError:
+		return "error"
+	case SeverityWarning:
+		return "warning"
+	case SeverityInfo:
+		return "info"
+	}
+	return fmt.Sprintf("Severity(%d)", int(s))
+}
+
+func (s Severity) color() string {
+	switch s {
+	case SeverityWarning:
+		return ansiYellow
+	case SeverityInfo:
+		return ansiBlue
+	}
+	return ansiRed
+}
+
+// LexError is an error reported by a state with L.Error,
+// or a warning reported with L.Warnf or L.Infof.
+type LexError struct {
+	Msg       string
+	Line, Col int
+	// LineText is the line of the error, as far as it was read.
+	LineText string
+	Severity Severity
+	// EndLine and EndCol locate the end of the value of the error, excluded.
+	EndLine, EndCol int
+	// Columns is the unit of Col and EndCol.
+	Columns ColumnUnit
+	// File is the path of the lexed file, if known, see Project,
+	// or the name of the included source, see L.Include.
+	File string
+}
+
+func (e *LexError) Error() string {
+	return e.Msg
+}
+
+// Snippet renders the line of the error with carets under its value.
+//
+//	3:5: unexpected token '1'
+//		foo 1
+//		    ^
+func (e *LexError) Snippet() string {
+	return e.render(false)
+}
+
+const (
+	ansiReset     = "\x1b[0m"
+	ansiBold      = "\x1b[1m"
+	ansiUnderline = "\x1b[4m"
+	ansiRed       = "\x1b[31m"
+	ansiYellow    = "\x1b[33m"
+	ansiBlue      = "\x1b[34m"
+)
+
+// runeCol returns the column col counted in runes of LineText.
+func (e *LexError) runeCol(col int) int {
+	if e.Columns == ColumnRunes {
+		return col
+	}
+	n, width := 1, 1
+	for _, r := range e.LineText {
+		if width >= col {
+			break
+		}
+		if e.Columns == ColumnBytes {
+			width += utf8.RuneLen(r)
+		} else {
+			width += utf16.RuneLen(r)
+		}
+		n++
+	}
+	return n
+}
+
+// span returns the runes of LineText underlined by the error, from col to end
+// excluded, up to the end of the line if the error spans several lines.
+// It has at least one rune.
+func (e *LexError) span() (col, end int) {
+	col = e.runeCol(e.Col)
+	if e.EndLine > e.Line {
+		end = utf8.RuneCountInString(e.LineText) + 1
+	} else {
+		end = e.runeCol(e.EndCol)
+	}
+	return col, max(end, col+1)
+}
+
+func (e *LexError) render(color bool) string {
+	severity := e.Severity.color()
+	var (
+		text  strings.Builder
+		caret strings.Builder
+		style = func(s string) {
+			if color {
+				text.WriteString(s)
+			}
+		}
+	)
+	col, end := e.span()
+	runes := []rune(e.LineText)
+	for i, r := range runes {
+		if i == col-1 {
+			style(ansiUnderline + severity)
+		}
+		text.WriteRune(r)
+		if i >= col-1 && i == min(end-1, len(runes))-1 {
+			style(ansiReset)
+		}
+		if i >= col-1 {
+			continue
+		}
+		if r == '\t' {
+			caret.WriteRune('\t')
+		} else {
+			caret.WriteRune(' ')
+		}
+	}
+	carets := strings.Repeat("^", end-col)
+	pos := fmt.Sprintf("%d:%d:", e.Line, e.Col)
+	if e.File != "" {
+		pos = e.File + ":" + pos
+	}
+	if !color {
+		if e.Severity != SeverityError {
+			pos += " " + e.Severity.String() + ":"
+		}
+		return fmt.Sprintf("%s %s\n\t%s\n\t%s%s\n", pos, e.Msg, text.String(), caret.String(), carets)
+	}
+	return fmt.Sprintf("%s%s%s %s%s%s:%s %s\n\t%s\n\t%s%s%s%s\n",
+		ansiBold, pos, ansiReset, ansiBold, severity, e.Severity, ansiReset, e.Msg,
+		text.String(), caret.String(), severity, carets, ansiReset)
+}
+
+// Diagnostic is an error, a warning or an info reported by a lexer.
+type Diagnostic = LexError
+
+// Diagnostics is a list of diagnostics.
+type Diagnostics []*Diagnostic
+
+// Diagnostics returns the errors, warnings and infos reported so far,
+// ordered by position.
+func (l *L) Diagnostics() Diagnostics {
+	return MergeDiagnostics(l.diagnostics)
+}
+
+// MergeDiagnostics returns the diagnostics of all ds, ordered by position.
+func MergeDiagnostics(ds ...Diagnostics) Diagnostics {
+	var ret Diagnostics
+	for _, d := range ds {
+		ret = append(ret, d...)
+	}
+	ret.Sort()
+	return ret
+}
+
+// Sort orders d by file and position, reports at the same position keep their order.
+func (d Diagnostics) Sort() {
+	sort.SliceStable(d, func(i, j int) bool {
+		if d[i].File != d[j].File {
+			return d[i].File < d[j].File
+		}
+		if d[i].Line != d[j].Line {
+			return d[i].Line < d[j].Line
+		}
+		return d[i].Col < d[j].Col
+	})
+}
+
+// Filter returns the diagnostics of d matching f.
+func (d Diagnostics) Filter(f func(*Diagnostic) bool) Diagnostics {
+	var ret Diagnostics
+	for _, e := range d {
+		if f(e) {
+			ret = append(ret, e)
+		}
+	}
+	return ret
+}
+
+// Errors returns the diagnostics of d with SeverityError.
+func (d Diagnostics) Errors() Diagnostics {
+	return d.Filter(func(e *Diagnostic) bool {
+		return e.Severity == SeverityError
+	})
+}
+
+// InFile returns the diagnostics of d reported in the file at path.
+func (d Diagnostics) InFile(path string) Diagnostics {
+	return d.Filter(func(e *Diagnostic) bool {
+		return e.File == path
+	})
+}
+
+// AtLeast returns the diagnostics of d with severity s or a more severe one.
+func (d Diagnostics) AtLeast(s Severity) Diagnostics {
+	return d.Filter(func(e *Diagnostic) bool {
+		return e.Severity <= s
+	})
+}
+
+// Err returns the errors of d joined with errors.Join,
+// or nil if d has no errors. The result implements Unwrap() []error.
+func (d Diagnostics) Err() error {
+	var errs []error
+	for _, e := range d.Errors() {
+		errs = append(errs, e)
+	}
+	return errors.Join(errs...)
+}
+
+// Errs returns all the errors reported so far, see Diagnostics.Err.
+// Err only holds the last one.
+func (l *L) Errs() error {
+	return l.Diagnostics().Err()
+}
+
+// Renderer writes the snippets of errors, colored with ANSI sequences
+// if Color is set.
+type Renderer struct {
+	w     io.Writer
+	Color bool
+}
+
+// NewRenderer creates a Renderer writing to w, it enables Color
+// when w is a terminal and the NO_COLOR environment variable is not set.
+func NewRenderer(w io.Writer) *Renderer {
+	return &Renderer{w: w, Color: isTerminal(w) && os.Getenv("NO_COLOR") == ""}
+}
+
+// Render writes the snippet of err, err which are not a *LexError
+// are written as is. The errors joined in err are rendered one by one.
+func (r *Renderer) Render(err error) error {
+	if j, ok := err.(interface{ Unwrap() []error }); ok {
+		for _, e := range j.Unwrap() {
+			if werr := r.Render(e); werr != nil {
+				return werr
+			}
+		}
+		return nil
+	}
+	var s string
+	if e, ok := err.(*LexError); ok {
+		s = e.render(r.Color)
+	} else {
+		s = err.Error() + "\n"
+	}
+	_, werr := io.WriteString(r.w, s)
+	return werr
+}
+
+func isTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	stat, err := f.Stat()
+	return err == nil && stat.Mode()&os.ModeCharDevice != 0
+}
@@ -0,0 +1,279 @@
+package lexer
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func Test_LexErrorSnippet(t *testing.T) {
+	l := New(bytes.NewBufferString("1.a\n\t2.b,c"), numberState)
+	l.ErrorHandler = func(e string) {}
+	_, err := l.All()
+
+	var lerr *LexError
+	if !errors.As(err, &lerr) {
+		t.Errorf("Expected a *LexError, but got %#v", err)
+		return
+	}
+	if lerr.Line != 2 || lerr.Col != 5 || lerr.LineText != "\t2.b," {
+		t.Errorf("Unexpected error location %#v", lerr)
+		return
+	}
+	expected := "2:5: unexpected token ','\n\t\t2.b,\n\t\t   ^\n"
+	if lerr.Snippet() != expected {
+		t.Errorf("Expected %q but got %q", expected, lerr.Snippet())
+		return
+	}
+}
+
+func Test_Renderer(t *testing.T) {
+	err := &LexError{Msg: "unexpected token ','", Line: 1, Col: 2, LineText: "a,b"}
+
+	var out bytes.Buffer
+	r := NewRenderer(&out)
+	if r.Color {
+		t.Error("Expected no color for a buffer")
+		return
+	}
+	r.Render(err)
+	if out.String() != err.Snippet() {
+		t.Errorf("Expected %q but got %q", err.Snippet(), out.String())
+		return
+	}
+
+	out.Reset()
+	r.Color = true
+	r.Render(err)
+	expected := "\x1b[1m1:2:\x1b[0m \x1b[1m\x1b[31merror:\x1b[0m unexpected token ','\n" +
+		"\ta\x1b[4m\x1b[31m,\x1b[0mb\n" +
+		"\t \x1b[31m^\x1b[0m\n"
+	if out.String() != expected {
+		t.Errorf("Expected %q but got %q", expected, out.String())
+		return
+	}
+}
+
+func Test_LexerWarnings(t *testing.T) {
+	state := func(l *L) StateFunc {
+		l.Take("0123456789")
+		if l.Current() == "007" {
+			l.Warnf("suspicious literal %q", l.Current())
+		}
+		l.Emit(NumberToken)
+		return nil
+	}
+	l := New(bytes.NewBufferString("007"), state)
+	var warnings []*LexError
+	l.WarningHandler = func(e *LexError) {
+		warnings = append(warnings, e)
+	}
+	if _, err := l.All(); err != nil {
+		t.Errorf("Unexpected error %v", err)
+		return
+	}
+	if len(warnings) != 1 || warnings[0].Severity != SeverityWarning {
+		t.Errorf("Expected a warning, but got %v", warnings)
+		return
+	}
+	expected := "1:1: warning: suspicious literal \"007\"\n\t007\n\t^^^\n"
+	if warnings[0].Snippet() != expected {
+		t.Errorf("Expected %q but got %q", expected, warnings[0].Snippet())
+		return
+	}
+}
+
+func Test_LexerMaxErrors(t *testing.T) {
+	rules, _ := NewRules(
+		Rule{Type: IdentToken, Pattern: `[a-z]+`},
+		Rule{Type: 10, Fallback: true, Action: func(l *L) {
+			l.Error("invalid input")
+		}},
+	)
+	l := New(bytes.NewBufferString("a1b2c3d4"), rules.State)
+	l.MaxErrors = 2
+	var errs []string
+	l.ErrorHandler = func(e string) {
+		errs = append(errs, e)
+	}
+	l.All()
+
+	expected := "[invalid input invalid input too many errors]"
+	if fmt.Sprint(errs) != expected {
+		t.Errorf("Expected %v but got %v", expected, errs)
+		return
+	}
+	if l.Err.Error() != "too many errors" {
+		t.Errorf("Expected %q but got %q", "too many errors", l.Err)
+		return
+	}
+}
+
+func Test_LexerMaxBuffer(t *testing.T) {
+	state := func(l *L) StateFunc {
+		l.TakeUntilByte('"')
+		l.Emit(IdentToken)
+		return nil
+	}
+	l := NewString("abcdefgh", state)
+	l.MaxBuffer = 4
+	var errs []string
+	l.ErrorHandler = func(e string) {
+		errs = append(errs, e)
+	}
+	tokens, err := l.All()
+
+	expected := "[buffer limit of 4 runes exceeded]"
+	if fmt.Sprint(errs) != expected {
+		t.Errorf("Expected %v but got %v", expected, errs)
+		return
+	}
+	if err == nil || len(tokens) != 1 || tokens[0].Value != "abcd" {
+		t.Errorf("Unexpected result %v %v", tokens, err)
+		return
+	}
+}
+
+func Test_LexerErrs(t *testing.T) {
+	rules, _ := NewRules(
+		Rule{Type: IdentToken, Pattern: `[a-z]+`},
+		Rule{Type: 10, Fallback: true, Action: func(l *L) {
+			l.Error("invalid input")
+		}},
+	)
+	l := New(bytes.NewBufferString("a1b2"), rules.State)
+	l.ErrorHandler = func(string) {}
+	l.Warnf("not an error")
+	l.All()
+
+	err := l.Errs()
+	j, ok := err.(interface{ Unwrap() []error })
+	if !ok || len(j.Unwrap()) != 2 {
+		t.Errorf("Expected 2 joined errors but got %v", err)
+		return
+	}
+	var e *LexError
+	if !errors.As(err, &e) || e.Col != 3 {
+		t.Errorf("Expected the first error at column %v but got %v", 3, e.Col)
+		return
+	}
+
+	var out bytes.Buffer
+	NewRenderer(&out).Render(err)
+	if strings.Count(out.String(), "invalid input") != 2 {
+		t.Errorf("Expected 2 rendered errors but got %q", out.String())
+		return
+	}
+
+	l = New(bytes.NewBufferString("a"), rules.State)
+	l.All()
+	if err := l.Errs(); err != nil {
+		t.Errorf("Unexpected error %v", err)
+		return
+	}
+}
+
+func Test_LexerDiagnostics(t *testing.T) {
+	state := func(l *L) StateFunc {
+		l.Take(" ")
+		l.Ignore()
+		l.Take("0123456789")
+		switch l.Current() {
+		case "":
+			if l.Next() == EOFRune {
+				return nil
+			}
+			l.Error("not a number")
+			l.Ignore()
+			return l.startState
+		case "007":
+			l.Warnf("suspicious literal")
+		}
+		l.Emit(NumberToken)
+		return l.startState
+	}
+	l := New(bytes.NewBufferString("1 007 x 2"), state)
+	l.ErrorHandler = func(string) {}
+	l.All()
+
+	other := Diagnostics{{Msg: "first", Line: 1, Col: 1, EndLine: 1, EndCol: 1}}
+	var got []string
+	for _, d := range MergeDiagnostics(l.Diagnostics(), other) {
+		got = append(got, fmt.Sprintf("%v:%v-%v %v %v", d.Line, d.Col, d.EndCol, d.Severity, d.Msg))
+	}
+	expected := "[1:1-1 error first 1:3-6 warning suspicious literal 1:7-8 error not a number]"
+	if fmt.Sprint(got) != expected {
+		t.Errorf("Expected %v but got %v", expected, got)
+		return
+	}
+	if errs := l.Diagnostics().Errors(); len(errs) != 1 {
+		t.Errorf("Expected 1 error but got %v", len(errs))
+		return
+	}
+}
+
+func Test_LexerColumns(t *testing.T) {
+	for _, c := range []struct {
+		unit ColumnUnit
+		cols string
+	}{
+		{ColumnRunes, "[1 4]"},
+		{ColumnBytes, "[1 8]"},
+		{ColumnUTF16, "[1 5]"},
+	} {
+		l := NewString("é😀 a !", func(l *L) StateFunc {
+			for {
+				l.Take(" ")
+				l.Ignore()
+				switch r := l.Next(); r {
+				case EOFRune:
+					return nil
+				case '!':
+					l.Error("unexpected")
+					return nil
+				}
+				l.TakeUntilByte(' ')
+				l.Emit(IdentToken)
+			}
+		})
+		l.Columns = c.unit
+		var cols []int
+		l.ErrorHandler = func(string) {}
+		l.Scan(func(tok Token) {
+			cols = append(cols, tok.Col)
+		})
+		if fmt.Sprint(cols) != c.cols {
+			t.Errorf("Expected %v but got %v", c.cols, cols)
+			return
+		}
+		if caret := "\t     ^\n"; !strings.HasSuffix(l.Err.(*LexError).Snippet(), caret) {
+			t.Errorf("Expected the caret %q in %q", caret, l.Err.(*LexError).Snippet())
+			return
+		}
+	}
+}
+
+func Test_LexErrorSpan(t *testing.T) {
+	for _, c := range []struct {
+		src, take, expected string
+	}{
+		{"1 é€x 2", " é€x", "1:3: bad\n\t1 é€x \n\t  ^^^\n"},
+		{"1 ab\ncd", " ab\ncd", "1:3: bad\n\t1 ab\n\t  ^^\n"},
+	} {
+		l := NewString(c.src, func(l *L) StateFunc {
+			l.Take("1 ")
+			l.Ignore()
+			l.Take(c.take[1:])
+			l.Error("bad")
+			return nil
+		}, WithColumns(ColumnBytes))
+		l.ErrorHandler = func(string) {}
+		l.All()
+		if s := l.Err.(*LexError).Snippet(); s != c.expected {
+			t.Errorf("Expected %q but got %q", c.expected, s)
+			return
+		}
+	}
+}
@@ -0,0 +1,128 @@
+package lexer
+
+import (
+	"errors"
+	"fmt"
+	"unicode"
+)
+
+// EscapeSet defines the escape sequences decoded by ScanEscape,
+// they start with a backslash.
+type EscapeSet struct {
+	// Simple maps the rune following the backslash to its value, such as 'n' to '\n'.
+	Simple map[rune]rune
+	// Hex decodes \xHH.
+	Hex bool
+	// Unicode decodes \uHHHH and \UHHHHHHHH.
+	Unicode bool
+	// BracedUnicode decodes \u{H}, with 1 to 6 hexadecimal digits.
+	BracedUnicode bool
+	// Octal decodes \O, \OO and \OOO, up to \377.
+	Octal bool
+}
+
+// DefaultEscapes are the escape sequences of C like languages.
+var DefaultEscapes = &EscapeSet{
+	Simple: map[rune]rune{
+		'a': '\a', 'b': '\b', 'f': '\f', 'n': '\n', 'r': '\r', 't': '\t', 'v': '\v',
+		'\\': '\\', '\'': '\'', '"': '"',
+	},
+	Hex:           true,
+	Unicode:       true,
+	BracedUnicode: true,
+	Octal:         true,
+}
+
+// Escape is an escape sequence decoded by ScanEscape.
+type Escape struct {
+	Rune       rune
+	Start, End int // byte offsets of the sequence in the source
+}
+
+var errNotEscape = errors.New("not an escape sequence")
+
+// ScanEscape consumes the escape sequence of set at the current position
+// and returns its value, with its span to locate the errors.
+// The invalid sequences are consumed. With AppendRune it decodes the string literals:
+//
+//	for l.Peek() != '"' {
+//		if l.Peek() == '\\' {
+//			e, err := lexer.ScanEscape(l, lexer.DefaultEscapes)
+//			...
+//			l.AppendRune(e.Rune)
+//		} else {
+//			l.AppendRune(l.Next())
+//		}
+//	}
+func ScanEscape(l *L, set *EscapeSet) (e Escape, err error) {
+	e.Start = l.byteOffset(l.position)
+	if l.Peek() != '\\' {
+		e.End = e.Start
+		return e, errNotEscape
+	}
+	l.Next()
+	r := l.Next()
+	switch v, ok := set.Simple[r]; {
+	case ok:
+		e.Rune = v
+	case r == 'x' && set.Hex:
+		e.Rune, err = scanHexRune(l, 2, 2)
+	case r == 'u' && set.BracedUnicode && l.Peek() == '{':
+		l.Next()
+		if e.Rune, err = scanHexRune(l, 1, 6); l.Next() != '}' {
+			l.Rewind()
+			if err == nil {
+				err = errors.New("escape sequence is not terminated by '}'")
+			}
+		}
+	case r == 'u' && set.Unicode:
+		e.Rune, err = scanHexRune(l, 4, 4)
+	case r == 'U' && set.Unicode:
+		e.Rune, err = scanHexRune(l, 8, 8)
+	case r >= '0' && r <= '7' && set.Octal:
+		e.Rune = r - '0'
+		for i := 0; i < 2 && l.Peek() >= '0' && l.Peek() <= '7'; i++ {
+			e.Rune = e.Rune*8 + l.Next() - '0'
+		}
+		if e.Rune > 0377 {
+			err = fmt.Errorf("octal escape value %v > 255", e.Rune)
+		}
+	case r == EOFRune:
+		err = errors.New("escape sequence is not terminated")
+	default:
+		err = fmt.Errorf("unknown escape sequence \\%c", r)
+	}
+	if err == nil && (e.Rune < 0 || e.Rune > unicode.MaxRune || e.Rune >= 0xd800 && e.Rune < 0xe000) {
+		err = fmt.Errorf("escape sequence is an invalid code point %U", e.Rune)
+	}
+	e.End = l.byteOffset(l.position)
+	return e, err
+}
+
+// scanHexRune consumes from least to most hexadecimal digits and returns their value.
+func scanHexRune(l *L, least, most int) (rune, error) {
+	var v rune
+	n := 0
+	for ; n < most && digitValue(l.Peek()) < 16; n++ {
+		if d := rune(digitValue(l.Next())); v <= unicode.MaxRune {
+			v = v*16 + d
+		}
+	}
+	if n < least {
+		return v, fmt.Errorf("escape sequence expects %v hexadecimal digits, got %v", least, n)
+	}
+	if v > unicode.MaxRune {
+		return 0, fmt.Errorf("escape sequence value exceeds %U", unicode.MaxRune)
+	}
+	return v, nil
+}
+
+// byteOffset returns the offset in the source of the rune at pos in buf,
+// pos must be in the current value or at its end.
+func (l *L) byteOffset(pos int) int {
+	off := l.offset
+	for i := l.start; i < pos; i++ {
+		off += l.runeSize(i, l.buf[i])
+	}
+	return off
+}
@@ -0,0 +1,72 @@
+package lexer
+
+import (
+	"fmt"
+	"testing"
+)
+
+func Test_ScanEscape(t *testing.T) {
+	for _, c := range []struct {
+		src string
+		r   rune
+		end int
+		err string
+	}{
+		{`\n`, '\n', 2, ""},
+		{`\x41B`, 'A', 4, ""},
+		{`\u00e9`, 'é', 6, ""},
+		{`\U0001F600`, '😀', 10, ""},
+		{`\u{1F600}x`, '😀', 9, ""},
+		{`\101`, 'A', 4, ""},
+		{`\0`, 0, 2, ""},
+		{`\q`, 0, 2, `unknown escape sequence \q`},
+		{`\x4`, 4, 3, "escape sequence expects 2 hexadecimal digits, got 1"},
+		{`\u{1F600`, 0x1F600, 8, "escape sequence is not terminated by '}'"},
+		{`\ud800`, 0xd800, 6, "escape sequence is an invalid code point U+D800"},
+		{`\UFFFFFFFF`, 0, 10, "escape sequence value exceeds U+10FFFF"},
+		{`\U80000000`, 0, 10, "escape sequence value exceeds U+10FFFF"},
+		{`\u{110000}`, 0, 10, "escape sequence value exceeds U+10FFFF"},
+		{`\777`, 0777, 4, "octal escape value 511 > 255"},
+		{`\`, 0, 1, "escape sequence is not terminated"},
+		{`a`, 0, 0, "not an escape sequence"},
+	} {
+		l := NewString("é"+c.src, nil)
+		l.Next()
+		e, err := ScanEscape(l, DefaultEscapes)
+		got := fmt.Sprintf("%q %v-%v %v", e.Rune, e.Start, e.End, err)
+		expected := fmt.Sprintf("%q %v-%v %v", c.r, 2, 2+c.end, c.err)
+		if c.err == "" {
+			expected = fmt.Sprintf("%q %v-%v %v", c.r, 2, 2+c.end, nil)
+		}
+		if got != expected {
+			t.Errorf("Expected %v but got %v", expected, got)
+			return
+		}
+	}
+}
+
+func Test_ScanEscapeLiteral(t *testing.T) {
+	l := NewString(`"a\tb\u{e9}"`, func(l *L) StateFunc {
+		l.Next()
+		for r := l.Peek(); r != '"' && r != EOFRune; r = l.Peek() {
+			if r == '\\' {
+				e, err := ScanEscape(l, DefaultEscapes)
+				if err != nil {
+					l.Error(err.Error())
+					return nil
+				}
+				l.AppendRune(e.Rune)
+			} else {
+				l.AppendRune(l.Next())
+			}
+		}
+		l.Next()
+		l.Emit(IdentToken)
+		return nil
+	})
+	tokens, _ := l.All()
+	if len(tokens) != 1 || tokens[0].Value != "a\tbé" {
+		t.Errorf("Expected %q but got %v", "a\tbé", tokens)
+		return
+	}
+}
@@ -0,0 +1,49 @@
+package lexer
+
+import (
+	"bufio"
+	"io"
+	"os"
+)
+
+// NewFromFile creates a returns a lexer ready to parse the file at path.
+// The file is memory mapped when the platform supports it, otherwise it is
+// read through a buffer. Close must be called once the lexing ended.
+func NewFromFile(path string, start StateFunc, opts ...Option) (*L, error) {
+	src, err := FileSource(path)
+	if err != nil {
+		return nil, err
+	}
+	return NewSource(src, start, opts...), nil
+}
+
+// FileSource returns a RuneSource reading the file at path,
+// it is memory mapped if possible. It must be closed.
+func FileSource(path string) (RuneSource, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	if src, err := mmapFile(f); err == nil {
+		f.Close()
+		return src, nil
+	}
+	return &fileSource{RuneSource: ReaderSource(bufio.NewReader(f)), f: f}, nil
+}
+
+// Close closes the source of l, if it is an io.Closer.
+func (l *L) Close() error {
+	if c, ok := l.src.(io.Closer); ok {
+		return c.Close()
+	}
+	return nil
+}
+
+type fileSource struct {
+	RuneSource
+	f *os.File
+}
+
+func (s *fileSource) Close() error {
+	return s.f.Close()
+}
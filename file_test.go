@@ -0,0 +1,33 @@
+package lexer
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func Test_NewFromFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "src")
+	if err := os.WriteFile(path, []byte(`"héllo" 123.world`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	l, err := NewFromFile(path, nil)
+	if err != nil {
+		t.Errorf("Unexpected error %v", err)
+		return
+	}
+	defer l.Close()
+
+	l.Next()
+	l.Ignore()
+	l.TakeUntilByte('"')
+	if l.Current() != "héllo" {
+		t.Errorf("Expected %q but got %q", "héllo", l.Current())
+		return
+	}
+	if err := l.Close(); err != nil {
+		t.Errorf("Unexpected error %v", err)
+		return
+	}
+}
@@ -0,0 +1,106 @@
+package lexer
+
+import (
+	"sort"
+	"sync"
+)
+
+// FileID identifies a file of a Files registry, the IDs start at 1.
+type FileID int
+
+// Pos is a compact position, a byte offset in a file of a Files registry.
+type Pos struct {
+	File   FileID
+	Offset int
+}
+
+// Files assigns compact IDs to the source files, so the positions can be
+// stored as Pos and resolved later, as go/token.FileSet does.
+// The lines of a file are recorded as it is lexed, see Track.
+// It is safe for concurrent use.
+type Files struct {
+	mu    sync.RWMutex
+	files []*trackedFile
+	ids   map[string]FileID
+}
+
+type trackedFile struct {
+	name  string
+	lines []int // offsets of the line starts
+}
+
+// Add registers the file name and returns its ID,
+// the ID of a file already registered is kept.
+func (fs *Files) Add(name string) FileID {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	if id, ok := fs.ids[name]; ok {
+		return id
+	}
+	if fs.ids == nil {
+		fs.ids = map[string]FileID{}
+	}
+	fs.files = append(fs.files, &trackedFile{name: name, lines: []int{0}})
+	id := FileID(len(fs.files))
+	fs.ids[name] = id
+	return id
+}
+
+// Track registers the file name and records its lines as l reads it,
+// it returns the file ID. The token offsets of l can then be resolved with
+// Position. It must be called before the lexing starts, once the base offset
+// and the input normalizations of l are set.
+func (fs *Files) Track(name string, l *L) FileID {
+	id := fs.Add(name)
+	fs.mu.Lock()
+	fs.files[id-1].lines = []int{l.readbytes}
+	fs.mu.Unlock()
+	l.src = &lineSource{RuneSource: l.src, files: fs, id: id, base: l.readbytes}
+	return id
+}
+
+// Name returns the name of the file id, or an empty string if it is unknown.
+func (fs *Files) Name(id FileID) string {
+	fs.mu.RLock()
+	defer fs.mu.RUnlock()
+	if id < 1 || int(id) > len(fs.files) {
+		return ""
+	}
+	return fs.files[id-1].name
+}
+
+// Position resolves p to the name of its file, its line and its column,
+// the column is counted in bytes from 1. The lines of the file are known
+// as far as it was read.
+func (fs *Files) Position(p Pos) (name string, line, col int) {
+	fs.mu.RLock()
+	defer fs.mu.RUnlock()
+	if p.File < 1 || int(p.File) > len(fs.files) {
+		return "", 0, 0
+	}
+	f := fs.files[p.File-1]
+	i := sort.SearchInts(f.lines, p.Offset+1) - 1
+	if i < 0 {
+		i = 0
+	}
+	return f.name, i + 1, p.Offset - f.lines[i] + 1
+}
+
+// lineSource records the line starts of the file it reads.
+type lineSource struct {
+	RuneSource
+	files *Files
+	id    FileID
+	base  int
+}
+
+func (s *lineSource) ReadRune() (rune, int, error) {
+	r, size, err := s.RuneSource.ReadRune()
+	if err == nil && r == '\n' {
+		s.files.mu.Lock()
+		f := s.files.files[s.id-1]
+		f.lines = append(f.lines, s.base+int(s.RuneSource.Offset()))
+		s.files.mu.Unlock()
+	}
+	return r, size, err
+}
@@ -0,0 +1,36 @@
+package lexer
+
+import (
+	"fmt"
+	"testing"
+)
+
+func Test_Files(t *testing.T) {
+	rules, _ := NewRules(
+		Rule{Type: IdentToken, Pattern: `\pL+`},
+		Rule{Pattern: `\s+`, Skip: true},
+	)
+	var fs Files
+	var positions []Pos
+	for _, f := range []struct{ name, src string }{{"a.txt", "été\n  b"}, {"b.txt", "c\nd"}} {
+		l := NewString(f.src, rules.State)
+		id := fs.Track(f.name, l)
+		l.Scan(func(tok Token) {
+			positions = append(positions, Pos{File: id, Offset: tok.Offset})
+		})
+	}
+	if id := fs.Add("a.txt"); id != 1 {
+		t.Errorf("Expected %v but got %v", 1, id)
+		return
+	}
+	var got []string
+	for _, p := range positions {
+		name, line, col := fs.Position(p)
+		got = append(got, fmt.Sprintf("%v:%v:%v", name, line, col))
+	}
+	expected := "[a.txt:1:1 a.txt:2:3 b.txt:1:1 b.txt:2:1]"
+	if fmt.Sprint(got) != expected {
+		t.Errorf("Expected %v but got %v", expected, got)
+		return
+	}
+}
@@ -0,0 +1,55 @@
+package lexer
+
+import (
+	"context"
+	"io"
+	"time"
+)
+
+// Follow returns a reader of r which treats io.EOF as "no data yet",
+// it waits poll and retries until more data is appended to r, tail -f style.
+// The reader returns io.EOF once ctx is done, ending the lexing.
+//
+// As the lexer blocks while waiting, a partially consumed token
+// is resumed when the rest of its input arrives.
+func Follow(ctx context.Context, r io.Reader, poll time.Duration) io.Reader {
+	return &follower{ctx: ctx, r: r, poll: poll}
+}
+
+type follower struct {
+	ctx  context.Context
+	r    io.Reader
+	poll time.Duration
+	b    [1]byte
+	n    int // b was read by ready
+}
+
+func (f *follower) Read(p []byte) (int, error) {
+	if f.n > 0 && len(p) > 0 {
+		p[0], f.n = f.b[0], 0
+		return 1, nil
+	}
+	for {
+		n, err := f.r.Read(p)
+		if n > 0 || err != io.EOF {
+			return n, err
+		}
+		t := time.NewTimer(f.poll)
+		select {
+		case <-f.ctx.Done():
+			t.Stop()
+			return 0, io.EOF
+		case <-t.C:
+		}
+	}
+}
+
+// ready reads a byte ahead, if available, without waiting.
+func (f *follower) ready() bool {
+	if f.n == 0 {
+		var err error
+		f.n, err = f.r.Read(f.b[:])
+		return f.n > 0 || err != io.EOF
+	}
+	return true
+}
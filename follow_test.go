@@ -0,0 +1,61 @@
+package lexer
+
+import (
+	"bytes"
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+// growingBuffer is a bytes.Buffer safe for a concurrent writer.
+type growingBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *growingBuffer) Read(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Read(p)
+}
+
+func (b *growingBuffer) WriteString(s string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.buf.WriteString(s)
+}
+
+func Test_Follow(t *testing.T) {
+	var b growingBuffer
+	b.WriteString("12.a")
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	l := New(Follow(ctx, &b, time.Millisecond), numberState)
+	tokens := make(chan Token)
+	go func() {
+		l.Scan(func(tok Token) {
+			tokens <- tok
+		})
+		close(tokens)
+	}()
+
+	if tok := <-tokens; tok.Value != "12" {
+		t.Errorf("Expected %q but got %q", "12", tok.Value)
+		return
+	}
+	if tok := <-tokens; tok.Value != "." {
+		t.Errorf("Expected %q but got %q", ".", tok.Value)
+		return
+	}
+	time.Sleep(10 * time.Millisecond)
+	b.WriteString("bc ")
+	if tok := <-tokens; tok.Value != "abc" {
+		t.Errorf("Expected %q but got %q", "abc", tok.Value)
+		return
+	}
+	cancel()
+	for range tokens {
+	}
+}
@@ -0,0 +1,151 @@
+//go:build ignore
+
+// gen_nfc generates nfc_table.go from the Unicode character database,
+// UnicodeData.txt and CompositionExclusions.txt, fetched from unicode.org
+// or read from a local directory.
+//
+//	go run gen_nfc.go [-version 14.0.0] [-ucd dir]
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var (
+	version = flag.String("version", "14.0.0", "version of the Unicode character database")
+	ucd     = flag.String("ucd", "", "directory of the database files, they are fetched from unicode.org if empty")
+	out     = flag.String("o", "nfc_table.go", "output file")
+)
+
+type composition struct {
+	a, b, c rune
+}
+
+func main() {
+	flag.Parse()
+
+	excluded := map[rune]bool{}
+	err := readFile("CompositionExclusions.txt", func(fields []string) error {
+		c, err := parseRune(fields[0])
+		excluded[c] = true
+		return err
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	ccc := map[rune]int{}
+	var candidates []composition
+	err = readFile("UnicodeData.txt", func(fields []string) error {
+		if len(fields) < 6 {
+			return fmt.Errorf("invalid line %q", strings.Join(fields, ";"))
+		}
+		c, err := parseRune(fields[0])
+		if err != nil {
+			return err
+		}
+		if ccc[c], err = strconv.Atoi(fields[3]); err != nil {
+			return err
+		}
+		d := strings.Fields(fields[5])
+		if len(d) != 2 || strings.HasPrefix(d[0], "<") || excluded[c] {
+			return nil
+		}
+		a, err := parseRune(d[0])
+		if err != nil {
+			return err
+		}
+		b, err := parseRune(d[1])
+		candidates = append(candidates, composition{a, b, c})
+		return err
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// the decompositions starting with a non-starter are excluded too.
+	var table []composition
+	for _, x := range candidates {
+		if ccc[x.c] == 0 && ccc[x.a] == 0 {
+			table = append(table, x)
+		}
+	}
+	sort.Slice(table, func(i, j int) bool {
+		return table[i].c < table[j].c
+	})
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "// Code generated by gen_nfc.go; DO NOT EDIT.\n\n")
+	fmt.Fprintf(&b, "package lexer\n\n")
+	fmt.Fprintf(&b, "// compositions maps the pairs of runes to their canonical composition,\n")
+	fmt.Fprintf(&b, "// the Hangul syllables excepted. It is derived from the Unicode %v\n", *version)
+	fmt.Fprintf(&b, "// character database, without the composition exclusions.\n")
+	fmt.Fprintf(&b, "var compositions = map[[2]rune]rune{\n")
+	for _, x := range table {
+		fmt.Fprintf(&b, "{%#04x, %#04x}: %#04x,\n", x.a, x.b, x.c)
+	}
+	fmt.Fprintf(&b, "}\n")
+	src, err := format.Source(b.Bytes())
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile(*out, src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// readFile invokes f with the fields of each line of the database file name,
+// the comments and the empty lines are skipped.
+func readFile(name string, f func(fields []string) error) error {
+	var r io.Reader
+	if *ucd != "" {
+		file, err := os.Open(filepath.Join(*ucd, name))
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		r = file
+	} else {
+		res, err := http.Get("https://www.unicode.org/Public/" + *version + "/ucd/" + name)
+		if err != nil {
+			return err
+		}
+		defer res.Body.Close()
+		if res.StatusCode != http.StatusOK {
+			return fmt.Errorf("%v: %v", name, res.Status)
+		}
+		r = res.Body
+	}
+	s := bufio.NewScanner(r)
+	for s.Scan() {
+		line, _, _ := strings.Cut(s.Text(), "#")
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		fields := strings.Split(line, ";")
+		for i := range fields {
+			fields[i] = strings.TrimSpace(fields[i])
+		}
+		if err := f(fields); err != nil {
+			return fmt.Errorf("%v: %v", name, err)
+		}
+	}
+	return s.Err()
+}
+
+func parseRune(s string) (rune, error) {
+	c, err := strconv.ParseUint(s, 16, 32)
+	return rune(c), err
+}
@@ -0,0 +1,191 @@
+package lexer
+
+import "io"
+
+// TokenG is a Token with a typed payload.
+type TokenG[T any] struct {
+	Token
+	Payload T
+}
+
+// StateFuncG is the state function of a LG.
+type StateFuncG[T any] func(*LG[T]) StateFuncG[T]
+
+// LG is a lexer emitting tokens with a typed payload,
+// such as the parsed value of a number.
+type LG[T any] struct {
+	*L
+	payload  T
+	pending  bool      // payload goes with the next emitted token
+	payloads map[int]T // the payloads of the emitted tokens, by Index
+}
+
+// NewG creates a returns a lexer of TokenG ready to parse the given source code.
+// It is configured by opts, see New.
+func NewG[T any](src io.Reader, start StateFuncG[T], opts ...Option) *LG[T] {
+	g := &LG[T]{payloads: map[int]T{}}
+	g.L = New(src, g.adapt(start), opts...)
+	Wrap(g.L, Middleware{Emit: g.emit})
+	return g
+}
+
+// EmitPayload emits the current value as a token of type t
+// with the given payload.
+func (g *LG[T]) EmitPayload(t TokenType, payload T) {
+	g.payload, g.pending = payload, true
+	g.L.Emit(t)
+}
+
+// emit records the payload of the emitted token, by its Index,
+// so it follows the token when its delivery is delayed, see Begin.
+func (g *LG[T]) emit(t TokenType, value string, next func(t TokenType, value string)) {
+	if g.pending {
+		var zero T
+		g.payloads[g.L.index] = g.payload
+		g.payload, g.pending = zero, false
+	} else {
+		delete(g.payloads, g.L.index)
+	}
+	next(t, value)
+}
+
+//Scan Browses all tokens and invokes f for each of them.
+// It returns the errors reported by the lexer, if any, see L.Scan.
+func (g *LG[T]) Scan(f func(t TokenG[T])) error {
+	return g.L.Scan(func(t Token) {
+		f(g.token(t))
+	})
+}
+
+// All lexes the whole source and returns its tokens.
+func (g *LG[T]) All() ([]TokenG[T], error) {
+	var tokens []TokenG[T]
+	err := g.Scan(func(t TokenG[T]) {
+		tokens = append(tokens, t)
+	})
+	return tokens, err
+}
+
+// NextToken Reads until a token is met, it returns nil at EOF.
+func (g *LG[T]) NextToken() *TokenG[T] {
+	t := g.L.NextToken()
+	if t == nil {
+		return nil
+	}
+	tok := g.token(*t)
+	return &tok
+}
+
+// NextTokens Reads until at least one token is met, it returns []*TokenG[T]{nil} at EOF.
+func (g *LG[T]) NextTokens() []*TokenG[T] {
+	tokens := g.L.NextTokens()
+	ret := make([]*TokenG[T], len(tokens))
+	for i, t := range tokens {
+		if t != nil {
+			tok := g.token(*t)
+			ret[i] = &tok
+		}
+	}
+	return ret
+}
+
+// BatchNext fills dst with up to len(dst) tokens,
+// it returns the number of tokens read, 0 at EOF.
+func (g *LG[T]) BatchNext(dst []TokenG[T]) int {
+	n := 0
+	for n < len(dst) {
+		t, err := g.ReadToken()
+		if err != nil {
+			break
+		}
+		dst[n] = t
+		n++
+	}
+	return n
+}
+
+// ReadToken reads the next token, see L.ReadToken.
+func (g *LG[T]) ReadToken() (TokenG[T], error) {
+	t, err := g.L.ReadToken()
+	if err != nil {
+		return TokenG[T]{}, err
+	}
+	return g.token(t), nil
+}
+
+// token attaches the payload of t, emitted with it, if any.
+func (g *LG[T]) token(t Token) TokenG[T] {
+	tok := TokenG[T]{Token: t, Payload: g.payloads[t.Index]}
+	delete(g.payloads, t.Index)
+	return tok
+}
+
+func (g *LG[T]) adapt(s StateFuncG[T]) StateFunc {
+	if s == nil {
+		return nil
+	}
+	return func(*L) StateFunc {
+		return g.adapt(s(g))
+	}
+}
+
+// TypedToken is a Token of a user defined kind type.
+type TypedToken[K ~int] struct {
+	Type   K
+	Value  string
+	Line   int
+	Col    int
+	Offset int    // see Token.Offset
+	Index  int    // see Token.Index
+	State  string // see Token.State
+}
+
+// TypedStateFunc is the state function of a TypedL.
+type TypedStateFunc[K ~int] func(*TypedL[K]) TypedStateFunc[K]
+
+// TypedL is a lexer emitting tokens of a user defined kind type.
+type TypedL[K ~int] struct {
+	*L
+}
+
+// NewTyped creates a returns a lexer of TypedToken ready to parse the given source code.
+// It is configured by opts, see New.
+func NewTyped[K ~int](src io.Reader, start TypedStateFunc[K], opts ...Option) *TypedL[K] {
+	l := &TypedL[K]{}
+	l.L = New(src, l.adapt(start), opts...)
+	return l
+}
+
+// Emit emits the current value as a token of kind k.
+func (l *TypedL[K]) Emit(k K) {
+	l.L.Emit(TokenType(k))
+}
+
+//Scan Browses all tokens and invokes f for each of them.
+// It returns the errors reported by the lexer, if any, see L.Scan.
+func (l *TypedL[K]) Scan(f func(t TypedToken[K])) error {
+	return l.L.Scan(func(t Token) {
+		f(TypedToken[K]{
+			Type: K(t.Type), Value: t.Value, Line: t.Line, Col: t.Col,
+			Offset: t.Offset, Index: t.Index, State: t.State,
+		})
+	})
+}
+
+// All lexes the whole source and returns its tokens.
+func (l *TypedL[K]) All() ([]TypedToken[K], error) {
+	var tokens []TypedToken[K]
+	err := l.Scan(func(t TypedToken[K]) {
+		tokens = append(tokens, t)
+	})
+	return tokens, err
+}
+
+func (l *TypedL[K]) adapt(s TypedStateFunc[K]) StateFunc {
+	if s == nil {
+		return nil
+	}
+	return func(*L) StateFunc {
+		return l.adapt(s(l))
+	}
+}
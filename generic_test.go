@@ -0,0 +1,154 @@
+package lexer
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"testing"
+)
+
+func IntState(l *LG[int]) StateFuncG[int] {
+	l.Take(" ")
+	l.Ignore()
+	l.Take("0123456789")
+	if l.Current() == "" {
+		return nil
+	}
+	n, _ := strconv.Atoi(l.Current())
+	l.EmitPayload(NumberToken, n)
+	return IntState
+}
+
+func Test_LexerGeneric(t *testing.T) {
+	l := NewG(bytes.NewBufferString("12 34 5"), IntState)
+	tokens, err := l.All()
+	if err != nil {
+		t.Errorf("Unexpected error %v", err)
+		return
+	}
+
+	expected := []int{12, 34, 5}
+	if len(tokens) != len(expected) {
+		t.Errorf("Expected %v tokens but got %v", len(expected), len(tokens))
+		return
+	}
+	for i, n := range expected {
+		if tokens[i].Payload != n {
+			t.Errorf("Expected %v but got %v", n, tokens[i].Payload)
+			return
+		}
+		if tokens[i].Value != strconv.Itoa(n) {
+			t.Errorf("Expected %q but got %q", strconv.Itoa(n), tokens[i].Value)
+			return
+		}
+	}
+}
+
+type wordKind int
+
+const (
+	wordKindWord wordKind = iota + 1
+	wordKindSpace
+)
+
+func WordState(l *TypedL[wordKind]) TypedStateFunc[wordKind] {
+	switch r := l.Peek(); {
+	case r == EOFRune:
+		return nil
+	case r == ' ':
+		l.Take(" ")
+		l.Emit(wordKindSpace)
+	default:
+		l.Take("abcdefghijklmnopqrstuvwxyz")
+		l.Emit(wordKindWord)
+	}
+	return WordState
+}
+
+func Test_LexerTyped(t *testing.T) {
+	l := NewTyped(bytes.NewBufferString("ab  c"), WordState, WithBaseOffset(10))
+	tokens, err := l.All()
+	if err != nil {
+		t.Errorf("Unexpected error %v", err)
+		return
+	}
+
+	expected := []TypedToken[wordKind]{
+		{wordKindWord, "ab", 1, 1, 10, 0, ""},
+		{wordKindSpace, "  ", 1, 3, 12, 1, ""},
+		{wordKindWord, "c", 1, 5, 14, 2, ""},
+	}
+	if len(tokens) != len(expected) {
+		t.Errorf("Expected %v tokens but got %v", len(expected), len(tokens))
+		return
+	}
+	for i := range expected {
+		if tokens[i] != expected[i] {
+			t.Errorf("Expected %v but got %v", expected[i], tokens[i])
+			return
+		}
+	}
+}
+
+func Test_LexerGenericPull(t *testing.T) {
+	l := NewG(bytes.NewBufferString("12 34 5"), IntState, WithBaseOffset(10))
+	var got []int
+	for tok := l.NextToken(); tok != nil; tok = l.NextToken() {
+		got = append(got, tok.Payload, tok.Offset)
+	}
+	l = NewG(bytes.NewBufferString("12 34 5"), IntState)
+	for tok, err := l.ReadToken(); err == nil; tok, err = l.ReadToken() {
+		got = append(got, tok.Payload)
+	}
+	if fmt.Sprint(got) != "[12 10 34 13 5 16 12 34 5]" {
+		t.Errorf("Expected %v but got %v", "[12 10 34 13 5 16 12 34 5]", got)
+		return
+	}
+}
+
+func Test_LexerGenericTransaction(t *testing.T) {
+	// "a" is emitted with a payload, rolled back, then emitted without;
+	// "b", "c" and "d" are held by a transaction, "b" and "d" with a payload.
+	var state StateFuncG[int]
+	state = func(l *LG[int]) StateFuncG[int] {
+		switch l.Peek() {
+		case EOFRune:
+			return nil
+		case 'a':
+			l.Begin()
+			l.Next()
+			l.EmitPayload(IdentToken, 1)
+			l.Rollback()
+			l.Next()
+			l.Emit(IdentToken)
+		default:
+			l.Begin()
+			l.Next()
+			l.EmitPayload(IdentToken, 2)
+			l.Next()
+			l.Emit(IdentToken)
+			l.Next()
+			l.EmitPayload(IdentToken, 3)
+			l.Commit()
+		}
+		return state
+	}
+	l := NewG(bytes.NewBufferString("abcd"), state)
+	tokens, err := l.All()
+	var got []string
+	for _, tok := range tokens {
+		got = append(got, fmt.Sprintf("%v:%v", tok.Value, tok.Payload))
+	}
+	if err != nil || fmt.Sprint(got) != "[a:0 b:2 c:0 d:3]" {
+		t.Errorf("Expected %v but got %v %v", "[a:0 b:2 c:0 d:3]", got, err)
+		return
+	}
+}
+
+func Test_LexerGenericFastPaths(t *testing.T) {
+	l := NewG(bytes.NewBufferString("12"), IntState)
+	if l.wrapped.next != nil || l.wrapped.err != nil || l.wrapped.emit == nil {
+		t.Errorf("Expected only Emit to be wrapped, but got %+v", l.wrapped)
+		return
+	}
+}
@@ -0,0 +1,83 @@
+package lexer
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+)
+
+// Transition is an edge of the state graph, To is empty when From ended the lexing.
+type Transition struct {
+	From, To string
+	Count    int
+}
+
+// transition is the key of the counted transitions.
+type transition struct {
+	from, to string
+}
+
+func (l *L) trace(from, to string) {
+	if l.transitions == nil {
+		l.transitions = map[transition]int{}
+	}
+	l.transitions[transition{from, to}]++
+}
+
+// CurrentStateName returns the name of the state being executed,
+// or of the last executed state.
+func (l *L) CurrentStateName() string {
+	return StateName(l.current)
+}
+
+// Transitions returns the transitions observed while Trace was set,
+// sorted by names.
+func (l *L) Transitions() []Transition {
+	ret := make([]Transition, 0, len(l.transitions))
+	for t, n := range l.transitions {
+		ret = append(ret, Transition{From: t.from, To: t.to, Count: n})
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		if ret[i].From != ret[j].From {
+			return ret[i].From < ret[j].From
+		}
+		return ret[i].To < ret[j].To
+	})
+	return ret
+}
+
+// StatesVisited returns the sorted names of the states executed while Trace was set.
+func (l *L) StatesVisited() []string {
+	visited := map[string]bool{}
+	var ret []string
+	for t := range l.transitions {
+		if !visited[t.from] {
+			visited[t.from] = true
+			ret = append(ret, t.from)
+		}
+	}
+	sort.Strings(ret)
+	return ret
+}
+
+// ExportDOT writes the graph of the states transitions observed
+// while Trace was set, in the Graphviz DOT language.
+// Each edge is labeled with the number of times it was taken.
+func (l *L) ExportDOT(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "digraph %q {\n", l.graphName())
+	fmt.Fprintf(bw, "\t%q [shape=point];\n", "")
+	for _, t := range l.Transitions() {
+		fmt.Fprintf(bw, "\t%q -> %q [label=\"%d\"];\n", t.From, t.To, t.Count)
+	}
+	fmt.Fprintln(bw, "}")
+	return bw.Flush()
+}
+
+func (l *L) graphName() string {
+	if l.Name == "" {
+		return "lexer"
+	}
+	return l.Name
+}
@@ -0,0 +1,58 @@
+package lexer
+
+import (
+	"bytes"
+	"regexp"
+	"testing"
+)
+
+func Test_ExportDOT(t *testing.T) {
+	l := New(bytes.NewBufferString("123.hello  675.world"), numberState)
+	l.Trace = true
+	l.All()
+
+	var out bytes.Buffer
+	if err := l.ExportDOT(&out); err != nil {
+		t.Errorf("Unexpected error %v", err)
+		return
+	}
+	// strip the package path of the state names.
+	got := regexp.MustCompile(`"[^"]*\.`).ReplaceAllString(out.String(), `"`)
+	expected := `digraph "lexer" {
+	"" [shape=point];
+	"identState" -> "whitespaceState" [label="2"];
+	"numberState" -> "identState" [label="2"];
+	"whitespaceState" -> "" [label="1"];
+	"whitespaceState" -> "numberState" [label="1"];
+}
+`
+	if got != expected {
+		t.Errorf("Expected %q but got %q", expected, got)
+		return
+	}
+}
+
+func Test_StatesIntrospection(t *testing.T) {
+	l := New(bytes.NewBufferString("123.hello"), numberState)
+	l.Trace = true
+
+	var current []string
+	l.Scan(func(Token) {
+		current = append(current, l.CurrentStateName())
+	})
+
+	if len(current) != 3 || current[0] != StateName(numberState) || current[2] != StateName(identState) {
+		t.Errorf("Unexpected current states %v", current)
+		return
+	}
+	visited := l.StatesVisited()
+	if len(visited) != 3 || visited[0] != StateName(identState) {
+		t.Errorf("Unexpected visited states %v", visited)
+		return
+	}
+	transitions := l.Transitions()
+	if len(transitions) != 3 || transitions[0].Count != 1 {
+		t.Errorf("Unexpected transitions %v", transitions)
+		return
+	}
+}
@@ -0,0 +1,54 @@
+package lexer
+
+import "unicode"
+
+const zeroWidthJoiner = '\u200d'
+
+// NextGrapheme consumes the next user-perceived character and returns it,
+// it returns an empty string at EOF. A character is a rune followed by its
+// combining marks, variation selectors and emoji modifiers, an emoji ZWJ
+// sequence, a pair of regional indicators (a flag), or "\r\n".
+// It approximates the extended grapheme clusters of UAX #29,
+// the Hangul syllables and the Indic conjuncts are not grouped.
+func (l *L) NextGrapheme() string {
+	mark := l.position
+	prev := l.Next()
+	if prev == EOFRune {
+		return ""
+	}
+	indicators := 0
+	for {
+		if isRegionalIndicator(prev) {
+			indicators++
+		}
+		r := l.Next()
+		if r == EOFRune || !graphemeJoins(prev, r, indicators) {
+			l.Rewind()
+			break
+		}
+		prev = r
+	}
+	return string(l.buf[mark:l.position])
+}
+
+// graphemeJoins reports whether r continues the character ending with prev,
+// which has indicators regional indicators.
+func graphemeJoins(prev, r rune, indicators int) bool {
+	switch {
+	case prev == '\r':
+		return r == '\n'
+	case unicode.IsControl(prev) || unicode.IsControl(r):
+		return false
+	case prev == zeroWidthJoiner:
+		return true
+	case isRegionalIndicator(r):
+		return indicators == 1 && isRegionalIndicator(prev)
+	}
+	return unicode.In(r, unicode.Mn, unicode.Me, unicode.Mc) ||
+		r == zeroWidthJoiner ||
+		r >= 0x1f3fb && r <= 0x1f3ff // emoji modifiers
+}
+
+func isRegionalIndicator(r rune) bool {
+	return r >= 0x1f1e6 && r <= 0x1f1ff
+}
@@ -0,0 +1,33 @@
+package lexer
+
+// hooks are the observers registered on a lexer.
+type hooks struct {
+	emit  []func(t Token)
+	err   []func(e *LexError)
+	state []func(from, to string)
+	end   []func()
+}
+
+// OnEmit registers f to be invoked with each emitted token,
+// before the TokenHandler.
+func (l *L) OnEmit(f func(t Token)) {
+	l.hooks.emit = append(l.hooks.emit, f)
+}
+
+// OnError registers f to be invoked with each reported error, warning or info.
+func (l *L) OnError(f func(e *LexError)) {
+	l.hooks.err = append(l.hooks.err, f)
+}
+
+// OnEnd registers f to be invoked once the lexing ends,
+// including when it stops at MaxErrors or MaxBuffer.
+func (l *L) OnEnd(f func()) {
+	l.hooks.end = append(l.hooks.end, f)
+}
+
+// OnStateChange registers f to be invoked after each state execution,
+// with the names of the executed state and of the next state,
+// to is empty when the lexing ends.
+func (l *L) OnStateChange(f func(from, to string)) {
+	l.hooks.state = append(l.hooks.state, f)
+}
@@ -0,0 +1,27 @@
+package lexer
+
+import (
+	"bytes"
+	"testing"
+)
+
+func Test_LexerHooks(t *testing.T) {
+	l := New(bytes.NewBufferString("1.a,"), numberState)
+	l.ErrorHandler = func(string) {}
+
+	var emits, errs, changes int
+	l.OnEmit(func(Token) { emits++ })
+	l.OnEmit(func(Token) { emits++ })
+	l.OnError(func(*LexError) { errs++ })
+	var last string
+	l.OnStateChange(func(from, to string) {
+		changes++
+		last = to
+	})
+	l.All()
+
+	if emits != 6 || errs != 1 || changes != 3 || last != "" {
+		t.Errorf("Unexpected hooks calls emits=%v errs=%v changes=%v last=%q", emits, errs, changes, last)
+		return
+	}
+}
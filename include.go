@@ -0,0 +1,87 @@
+package lexer
+
+import (
+	"errors"
+	"fmt"
+	"io"
+)
+
+// Includer returns the source of name, see L.Include.
+type Includer func(name string) (RuneSource, error)
+
+// included is the outer source saved by Include.
+type included struct {
+	file      string
+	src       RuneSource
+	lookahead []rune
+	line, col int
+	offset    int
+	readbytes int
+	dropped   int
+	widths    []runeWidth
+	lineText  []rune
+}
+
+// Include lexes the source of name inline: the current value is ignored,
+// the source is resolved with Includer, and once it is consumed the lexing
+// resumes with the outer source. The positions of the tokens of an included
+// source are relative to it, File returns its name.
+// A token can not span the end of an included source.
+func (l *L) Include(name string) error {
+	if l.Includer == nil {
+		return errors.New("no Includer to resolve the included sources")
+	}
+	if name == l.file {
+		return fmt.Errorf("%q includes itself", name)
+	}
+	for _, inc := range l.includes {
+		if inc.file == name {
+			return fmt.Errorf("%q includes itself", name)
+		}
+	}
+	src, err := l.Includer(name)
+	if err != nil {
+		return err
+	}
+	l.Ignore()
+	l.includes = append(l.includes, included{
+		file:      l.file,
+		src:       l.src,
+		lookahead: append([]rune(nil), l.buf[l.position:]...),
+		line:      l.line,
+		col:       l.col,
+		offset:    l.offset,
+		readbytes: l.readbytes,
+		dropped:   l.dropped,
+		widths:    l.widths,
+		lineText:  l.lineText,
+	})
+	l.file, l.src = name, src
+	l.buf, l.start, l.position = l.buf[:0], 0, 0
+	l.line, l.col, l.offset, l.readbytes, l.dropped = 1, 1, 0, 0, 0
+	l.widths, l.lineText = nil, nil
+	l.rewind.clear()
+	return nil
+}
+
+// File returns the name of the included source being lexed, or an empty
+// string for the main source. It locates the emitted tokens
+// in the TokenHandler or an OnEmit hook.
+func (l *L) File() string {
+	return l.file
+}
+
+// endInclude resumes the lexing of the outer source, once the included
+// source is consumed.
+func (l *L) endInclude() {
+	if c, ok := l.src.(io.Closer); ok {
+		c.Close()
+	}
+	inc := l.includes[len(l.includes)-1]
+	l.includes = l.includes[:len(l.includes)-1]
+	l.file, l.src = inc.file, inc.src
+	l.buf, l.start, l.position = append(l.buf[:0], inc.lookahead...), 0, 0
+	l.line, l.col, l.offset, l.readbytes, l.dropped = inc.line, inc.col, inc.offset, inc.readbytes, inc.dropped
+	l.widths, l.lineText = inc.widths, inc.lineText
+	l.rewind.clear()
+}
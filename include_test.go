@@ -0,0 +1,104 @@
+package lexer
+
+import (
+	"fmt"
+	"testing"
+	"unicode"
+)
+
+func Test_Include(t *testing.T) {
+	sources := map[string]string{"inc": "x\n#sub y", "sub": "z", "self": "#self"}
+	var state StateFunc
+	state = func(l *L) StateFunc {
+		switch l.Next() {
+		case EOFRune:
+			return nil
+		case ' ', '\n':
+			l.Ignore()
+		case '#':
+			for unicode.IsLetter(l.Peek()) {
+				l.Next()
+			}
+			if err := l.Include(l.Current()[1:]); err != nil {
+				l.Error(err.Error())
+				l.Ignore()
+			}
+		default:
+			for unicode.IsLetter(l.Peek()) {
+				l.Next()
+			}
+			l.Emit(IdentToken)
+		}
+		return state
+	}
+	l := NewString("a #inc b\nc #self", state)
+	l.Includer = func(name string) (RuneSource, error) {
+		src, ok := sources[name]
+		if !ok {
+			return nil, fmt.Errorf("%q not found", name)
+		}
+		return StringSource(src), nil
+	}
+	var errs []string
+	l.ErrorHandler = func(e string) {
+		errs = append(errs, e)
+	}
+	var got []string
+	l.Scan(func(tok Token) {
+		got = append(got, fmt.Sprintf("%v@%v:%v:%v:%v", tok.Value, l.File(), tok.Line, tok.Col, tok.Offset))
+	})
+	expected := "[a@:1:1:0 x@inc:1:1:0 z@sub:1:1:0 y@inc:2:6:7 b@:1:8:7 c@:2:1:9]"
+	if fmt.Sprint(got) != expected {
+		t.Errorf("Expected %v but got %v", expected, got)
+		return
+	}
+	if fmt.Sprint(errs) != `["self" includes itself]` {
+		t.Errorf("Expected %v but got %v", `["self" includes itself]`, errs)
+		return
+	}
+}
+
+func Test_IncludeFile(t *testing.T) {
+	var state StateFunc
+	state = func(l *L) StateFunc {
+		switch l.Next() {
+		case EOFRune:
+			return nil
+		case ' ', '\n':
+			l.Ignore()
+		case '#':
+			for unicode.IsLetter(l.Peek()) {
+				l.Next()
+			}
+			if err := l.Include(l.Current()[1:]); err != nil {
+				l.Error(err.Error())
+				l.Ignore()
+			}
+		default:
+			l.Emit(IdentToken)
+		}
+		return state
+	}
+	l := NewString("a #inc b", state)
+	l.Includer = func(name string) (RuneSource, error) {
+		if name != "inc" {
+			return nil, fmt.Errorf("%q not found", name)
+		}
+		return StringSource("x\n #nope"), nil
+	}
+	l.ErrorHandler = func(string) {}
+	var got []string
+	for tok, err := l.ReadToken(); err == nil; tok, err = l.ReadToken() {
+		got = append(got, fmt.Sprintf("%v@%v:%v:%v", tok.Value, tok.File, tok.Line, tok.Col))
+	}
+	expected := "[a@:1:1 x@inc:1:1 b@:1:8]"
+	if fmt.Sprint(got) != expected {
+		t.Errorf("Expected %v but got %v", expected, got)
+		return
+	}
+	d := l.Diagnostics()
+	if len(d) != 1 || d[0].File != "inc" || d[0].Line != 2 || d[0].Col != 2 {
+		t.Errorf("Expected an error at inc:2:2 but got %v", d)
+		return
+	}
+}
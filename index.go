@@ -0,0 +1,104 @@
+package lexer
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// indexMagic starts the token index files.
+const indexMagic = "SLIX2"
+
+// ErrStaleIndex is returned by ReadIndex when the source
+// differs from the indexed source.
+var ErrStaleIndex = errors.New("lexer: the index does not match the source")
+
+// WriteIndex encodes the tokens of s into w as a compact index,
+// without their values, see ReadIndex. The index starts with the length
+// and the sha256 of the source and the number of tokens, each token is then encoded as
+// a varint type, the varint distance from the end of the previous token
+// to its start, the uvarint length, line increment and column.
+func WriteIndex(w io.Writer, s *LazyTokens) error {
+	bw := bufio.NewWriter(w)
+	buf := append([]byte(nil), indexMagic...)
+	buf = binary.AppendUvarint(buf, uint64(len(s.Source)))
+	sum := sha256.Sum256(s.Source)
+	buf = append(buf, sum[:]...)
+	buf = binary.AppendUvarint(buf, uint64(len(s.Tokens)))
+	end, line := 0, 1
+	for _, t := range s.Tokens {
+		buf = binary.AppendVarint(buf, int64(t.Type))
+		buf = binary.AppendVarint(buf, int64(t.Start-end))
+		buf = binary.AppendUvarint(buf, uint64(t.End-t.Start))
+		buf = binary.AppendUvarint(buf, uint64(t.Line-line))
+		buf = binary.AppendUvarint(buf, uint64(t.Col))
+		end, line = t.End, t.Line
+		if _, err := bw.Write(buf); err != nil {
+			return err
+		}
+		buf = buf[:0]
+	}
+	if _, err := bw.Write(buf); err != nil {
+		return err
+	}
+	return bw.Flush()
+}
+
+// ReadIndex decodes an index written by WriteIndex,
+// the values of its tokens are read from src.
+func ReadIndex(r io.Reader, src []byte) (*LazyTokens, error) {
+	br := bufio.NewReader(r)
+	magic := make([]byte, len(indexMagic))
+	if _, err := io.ReadFull(br, magic); err != nil {
+		return nil, unexpectedEOF(err)
+	}
+	if string(magic) != indexMagic {
+		return nil, fmt.Errorf("invalid token index header %q", magic)
+	}
+	size, err := binary.ReadUvarint(br)
+	if err != nil {
+		return nil, unexpectedEOF(err)
+	}
+	var sum [sha256.Size]byte
+	if _, err := io.ReadFull(br, sum[:]); err != nil {
+		return nil, unexpectedEOF(err)
+	}
+	if want := sha256.Sum256(src); size != uint64(len(src)) || !bytes.Equal(sum[:], want[:]) {
+		return nil, ErrStaleIndex
+	}
+	n, err := binary.ReadUvarint(br)
+	if err != nil {
+		return nil, unexpectedEOF(err)
+	}
+	s := &LazyTokens{Source: src, Tokens: make([]LazyToken, 0, min(n, size))}
+	end, line := 0, 1
+	for i := uint64(0); i < n; i++ {
+		typ, err := binary.ReadVarint(br)
+		if err != nil {
+			return nil, unexpectedEOF(err)
+		}
+		start, err := binary.ReadVarint(br)
+		if err != nil {
+			return nil, unexpectedEOF(err)
+		}
+		var pos [3]uint64
+		for i := range pos {
+			pos[i], err = binary.ReadUvarint(br)
+			if err != nil {
+				return nil, unexpectedEOF(err)
+			}
+		}
+		t := LazyToken{Type: TokenType(typ), Start: end + int(start), Line: line + int(pos[1]), Col: int(pos[2])}
+		t.End = t.Start + int(pos[0])
+		if t.Start < 0 || t.End < t.Start || t.End > len(src) {
+			return nil, fmt.Errorf("invalid token index range %v-%v", t.Start, t.End)
+		}
+		s.Tokens = append(s.Tokens, t)
+		end, line = t.End, t.Line
+	}
+	return s, nil
+}
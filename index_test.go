@@ -0,0 +1,50 @@
+package lexer
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+)
+
+func Test_Index(t *testing.T) {
+	rules, _ := NewRules(
+		Rule{Type: IdentToken, Pattern: `\pL+`},
+		Rule{Type: OpToken, Pattern: `[+=]`},
+		Rule{Pattern: `\s+`, Skip: true},
+	)
+	src := []byte("été = a +\nb")
+	s := &LazyTokens{Source: src}
+	l := NewBytes(src, rules.State)
+	l.Lazy(s)
+	l.Scan(func(Token) {})
+	var b bytes.Buffer
+	if err := WriteIndex(&b, s); err != nil {
+		t.Errorf("Expected no error but got %v", err)
+		return
+	}
+	got, err := ReadIndex(bytes.NewReader(b.Bytes()), src)
+	if err != nil {
+		t.Errorf("Expected no error but got %v", err)
+		return
+	}
+	if fmt.Sprint(got.Tokens) != fmt.Sprint(s.Tokens) {
+		t.Errorf("Expected %v but got %v", s.Tokens, got.Tokens)
+		return
+	}
+	if got.Value(4) != "b" {
+		t.Errorf("Expected %q but got %q", "b", got.Value(4))
+		return
+	}
+	if _, err := ReadIndex(bytes.NewReader(b.Bytes()), src[1:]); err != ErrStaleIndex {
+		t.Errorf("Expected %v but got %v", ErrStaleIndex, err)
+		return
+	}
+	if _, err := ReadIndex(bytes.NewReader(b.Bytes()), []byte("été = b +\na")); err != ErrStaleIndex {
+		t.Errorf("Expected %v but got %v", ErrStaleIndex, err)
+		return
+	}
+	if _, err := ReadIndex(bytes.NewReader(b.Bytes()[:b.Len()-1]), src); err == nil {
+		t.Errorf("Expected an error but got nil")
+		return
+	}
+}
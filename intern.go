@@ -0,0 +1,34 @@
+package lexer
+
+import "strings"
+
+// InternTable shares a single string instance between repeated token values.
+// It can be shared by several lexers, but it is not safe for concurrent use.
+// The zero value is an empty table ready to use.
+type InternTable struct {
+	values map[string]string
+}
+
+// NewInternTable creates an empty InternTable.
+func NewInternTable() *InternTable {
+	return &InternTable{values: map[string]string{}}
+}
+
+// Intern returns the shared instance of s, a new value is copied
+// so the table does not keep the input of s alive.
+func (t *InternTable) Intern(s string) string {
+	if v, ok := t.values[s]; ok {
+		return v
+	}
+	if t.values == nil {
+		t.values = map[string]string{}
+	}
+	s = strings.Clone(s)
+	t.values[s] = s
+	return s
+}
+
+// Len returns the number of distinct values of the table.
+func (t *InternTable) Len() int {
+	return len(t.values)
+}
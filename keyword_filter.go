@@ -0,0 +1,46 @@
+package lexer
+
+// KeywordFilter promotes the identifiers of soft keywords to their keyword
+// type, or demotes their keyword tokens to identifiers, depending on the
+// neighbouring tokens. It delays each token until the next one is handled,
+// Flush must be called at the end of the stream.
+type KeywordFilter struct {
+	Ident    TokenType
+	Keywords Keywords
+	// Promote reports whether t is a keyword, given the previous and the next
+	// tokens, they are nil at the start and at the end of the stream.
+	Promote func(prev *Token, t Token, next *Token) bool
+	// Handler receives the filtered tokens.
+	Handler func(t Token)
+
+	prev, pending *Token
+}
+
+// Handle filters t, it is suitable as a TokenHandler or a Scan callback.
+func (f *KeywordFilter) Handle(t Token) {
+	if f.pending != nil {
+		f.deliver(&t)
+	}
+	f.pending = &t
+}
+
+// Flush delivers the last token.
+func (f *KeywordFilter) Flush() {
+	if f.pending != nil {
+		f.deliver(nil)
+	}
+}
+
+func (f *KeywordFilter) deliver(next *Token) {
+	t := *f.pending
+	f.pending = nil
+	if kw, ok := f.Keywords[t.Value]; ok && (t.Type == f.Ident || t.Type == kw) {
+		if f.Promote(f.prev, t, next) {
+			t.Type = kw
+		} else {
+			t.Type = f.Ident
+		}
+	}
+	f.prev = &t
+	f.Handler(t)
+}
@@ -0,0 +1,39 @@
+package lexer
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+)
+
+func Test_KeywordFilter(t *testing.T) {
+	const (
+		NewlineToken TokenType = iota + 10
+		MatchToken
+	)
+	rules, _ := NewRules(
+		Rule{Type: IdentToken, Pattern: `[a-z]+`},
+		Rule{Type: OpToken, Pattern: `=`},
+		Rule{Type: NewlineToken, Pattern: `\n`},
+		Rule{Pattern: ` +`, Skip: true},
+	)
+	var got []string
+	f := &KeywordFilter{
+		Ident:    IdentToken,
+		Keywords: Keywords{"match": MatchToken},
+		Promote: func(prev *Token, t Token, next *Token) bool {
+			return prev == nil || prev.Type == NewlineToken
+		},
+		Handler: func(t Token) {
+			got = append(got, fmt.Sprintf("%v:%v", t.Type, t.Value))
+		},
+	}
+	New(bytes.NewBufferString("match x\nx = match"), rules.State).Scan(f.Handle)
+	f.Flush()
+
+	expected := "[11:match 2:x 10:\n 2:x 1:= 2:match]"
+	if fmt.Sprint(got) != expected {
+		t.Errorf("Expected %q but got %q", expected, fmt.Sprint(got))
+		return
+	}
+}
@@ -0,0 +1,43 @@
+package lexer
+
+// LazyToken is a token holding the byte range of its value in the source
+// rather than the value, see L.Lazy.
+type LazyToken struct {
+	Type       TokenType
+	Start, End int // byte offsets of the value in the source
+	Line       int
+	Col        int
+}
+
+// LazyTokens are the tokens of Source, their values are sliced from it on demand.
+type LazyTokens struct {
+	Source []byte
+	Tokens []LazyToken
+}
+
+// Lazy makes l append its tokens to s rather than building their values,
+// the tokens are still delivered, with an empty Value. s.Source must be
+// the input of l, at offset 0, the values built with AppendRune are not kept.
+// It must be called before the lexing starts.
+func (l *L) Lazy(s *LazyTokens) {
+	l.lazy = s
+}
+
+// Value returns the value of the token i.
+func (s *LazyTokens) Value(i int) string {
+	t := s.Tokens[i]
+	return string(s.Source[t.Start:t.End])
+}
+
+// Token returns the token i, with its value.
+func (s *LazyTokens) Token(i int) Token {
+	t := s.Tokens[i]
+	return Token{
+		Type:   t.Type,
+		Value:  s.Value(i),
+		Line:   t.Line,
+		Col:    t.Col,
+		Offset: t.Start,
+		Index:  i,
+	}
+}
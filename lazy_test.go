@@ -0,0 +1,36 @@
+package lexer
+
+import (
+	"fmt"
+	"testing"
+)
+
+func Test_Lazy(t *testing.T) {
+	rules, _ := NewRules(
+		Rule{Type: IdentToken, Pattern: `\pL+`},
+		Rule{Type: OpToken, Pattern: `[+=]`},
+		Rule{Pattern: `\s+`, Skip: true},
+	)
+	src := []byte("été = a +\nb")
+	s := &LazyTokens{Source: src}
+	l := NewBytes(src, rules.State)
+	l.Lazy(s)
+	var values []string
+	l.Scan(func(tok Token) {
+		values = append(values, tok.Value)
+	})
+	if fmt.Sprintf("%q", values) != `["" "" "" "" ""]` {
+		t.Errorf("Expected empty values but got %q", values)
+		return
+	}
+	var got []string
+	for i := range s.Tokens {
+		tok := s.Token(i)
+		got = append(got, fmt.Sprintf("%q@%v:%v:%v", tok.Value, tok.Line, tok.Col, tok.Offset))
+	}
+	expected := `["été"@1:1:0 "="@1:5:6 "a"@1:7:8 "+"@1:9:10 "b"@2:1:12]`
+	if fmt.Sprint(got) != expected {
+		t.Errorf("Expected %v but got %v", expected, got)
+		return
+	}
+}
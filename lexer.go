@@ -28,9 +28,12 @@
 package lexer
 
 import (
-	"errors"
+	"context"
+	"fmt"
 	"io"
+	"strconv"
 	"strings"
+	"unicode"
 	"unicode/utf8"
 )
 
@@ -44,8 +47,14 @@ const (
 )
 
 type Token struct {
-	Type  TokenType
-	Value string
+	Type   TokenType
+	Value  string
+	Line   int
+	Col    int
+	Offset int    // byte offset in the source, see L.SetBaseOffset
+	Index  int    // sequence number of the token, from 0
+	State  string `json:",omitempty"` // name of the emitting state, see L.Provenance
+	File   string `json:",omitempty"` // name of the included source, see L.Include
 }
 
 func (t *Token) GetType() TokenType {
@@ -58,10 +67,80 @@ func (t *Token) String() string {
 	return t.GetValue()
 }
 
+// MarshalText encodes the token as type:"value"@line:col,
+// where the value is quoted with strconv.Quote.
+func (t Token) MarshalText() ([]byte, error) {
+	b := strconv.AppendInt(nil, int64(t.Type), 10)
+	b = append(b, ':')
+	b = strconv.AppendQuote(b, t.Value)
+	b = append(b, '@')
+	b = strconv.AppendInt(b, int64(t.Line), 10)
+	b = append(b, ':')
+	b = strconv.AppendInt(b, int64(t.Col), 10)
+	return b, nil
+}
+
+// UnmarshalText decodes a token encoded by MarshalText.
+func (t *Token) UnmarshalText(text []byte) error {
+	s := string(text)
+	i := strings.IndexByte(s, ':')
+	j := strings.LastIndexByte(s, '@')
+	if i < 0 || j < i {
+		return fmt.Errorf("invalid token %q", s)
+	}
+	k := strings.IndexByte(s[j:], ':')
+	if k < 0 {
+		return fmt.Errorf("invalid token %q", s)
+	}
+	k += j
+	typ, err := strconv.Atoi(s[:i])
+	if err != nil {
+		return fmt.Errorf("invalid token %q: %v", s, err)
+	}
+	value, err := strconv.Unquote(s[i+1 : j])
+	if err != nil {
+		return fmt.Errorf("invalid token %q: %v", s, err)
+	}
+	line, err := strconv.Atoi(s[j+1 : k])
+	if err != nil {
+		return fmt.Errorf("invalid token %q: %v", s, err)
+	}
+	col, err := strconv.Atoi(s[k+1:])
+	if err != nil {
+		return fmt.Errorf("invalid token %q: %v", s, err)
+	}
+	t.Type, t.Value, t.Line, t.Col = TokenType(typ), value, line, col
+	return nil
+}
+
+// L is the lexer.
+//
+// A L is not safe for concurrent use, including read only methods
+// such as Current or ReadBytes: NextToken, NextTokens and Scan
+// replace TokenHandler and share internal buffers with the state functions.
+// Use Synchronized to share a lexer between goroutines.
 type L struct {
-	source          io.Reader
+	src             RuneSource
 	start, position int
 	readbytes       int
+	line, col       int
+	offset          int // of the current value
+	index           int // of the next token
+	feeding         bool
+	overflow        bool   // MaxBuffer was exceeded
+	value           []rune // the current value, when built is set
+	built           bool   // the current value was appended, see AppendRune
+	delims          *delims
+	newlines        *newlines
+	lossless        bool        // the ignored values are emitted with the skipped type
+	skipped         TokenType   // see Lossless
+	dropped         int         // number of runes dropped from buf
+	widths          []runeWidth // of the buffered runes not encoded as read
+	lazy            *LazyTokens // see Lazy
+	file            string      // name of the included source, see Include
+	includes        []included  // the outer sources of the included one
+	sourceMap       *SourceMap  // locates the tokens in the preprocessed source
+	transactions    []transaction
 	buf             []rune
 	p               []byte
 	startState      StateFunc
@@ -69,89 +148,219 @@ type L struct {
 	// tokens          chan Token
 	TokenHandler func(t Token)
 	ErrorHandler func(e string)
-	rewind       runeStack
-	hasNext      bool
-	nextState    StateFunc
-	lastTokens   []*Token
+	// WarningHandler receives the warnings and infos, they are dropped if it is not set.
+	WarningHandler func(e *LexError)
+	Intern         *InternTable // shares the values of the emitted tokens, if set
+	UnicodeFold    bool         // fold cases of all unicode letters, not only ASCII
+	Name           string       // name of the grammar, for the pprof labels
+	PprofLabels    bool         // label the executions of the states with pprof
+	StateHook      StateHook    // times the executions of the states, if set
+	Provenance     bool         // stamp the tokens with the name of their state
+	Trace          bool         // count the transitions between the states, see ExportDOT
+	MaxErrors      int          // stop the lexing after MaxErrors errors, if set
+	MaxBuffer      int          // fail when the current value and its lookahead exceed MaxBuffer runes, if set
+	Columns        ColumnUnit   // unit of the columns, runes by default
+	Includer       Includer     // resolves the sources of Include, if set
+	rewind         runeStack
+	hasNext        bool
+	nextState      StateFunc
+	pending        []Token // tokens queued by the pull API
+	next           int     // index of the next pending token
+	progress       progress
+	takeChars      string
+	takeSet        asciiSet
+	takeASCII      bool // takeSet holds takeChars
+	recorder       *Recorder
+	current        StateFunc
+	stateName      string
+	transitions    map[transition]int
+	modes          []string
+	chained        [][]StateFunc // states left of the running chains, see Chain
+	lineText       []rune // the current line, up to the current value
+	errors         int
+	diagnostics    Diagnostics
+	hooks          hooks
+	wrapped        wrapped
+	replay         []RecordEvent
+}
+
+type progress struct {
+	every int
+	last  int
+	cb    func(bytesRead int64)
+}
+
+// New creates a returns a lexer ready to parse the given source code,
+// configured by opts.
+func New(src io.Reader, start StateFunc, opts ...Option) *L {
+	return NewSource(ReaderSource(src), start, opts...)
 }
 
-// New creates a returns a lexer ready to parse the given source code.
-func New(src io.Reader, start StateFunc) *L {
-	return &L{
-		source:     src,
+// NewSource creates a returns a lexer ready to parse src, configured by opts.
+func NewSource(src RuneSource, start StateFunc, opts ...Option) *L {
+	l := &L{
+		src:        src,
 		startState: start,
 		buf:        make([]rune, 0),
-		p:          make([]byte, 1),
 		start:      0,
 		position:   0,
 		readbytes:  0,
+		line:       1,
+		col:        1,
 		rewind:     newRuneStack(),
 	}
+	if rs, ok := src.(*readerSource); ok {
+		if sr, ok := rs.r.(*io.SectionReader); ok {
+			_, base, _ := sr.Outer()
+			cur, _ := sr.Seek(0, io.SeekCurrent)
+			l.SetBaseOffset(int(base + cur))
+		}
+	}
+	for _, opt := range opts {
+		opt(l)
+	}
+	return l
+}
+
+// SetBaseOffset sets the offset of the source within its underlying input,
+// so the tokens offsets and ReadBytes are absolute. It must be called before
+// the lexing starts, it is detected for io.SectionReader sources.
+func (l *L) SetBaseOffset(offset int) {
+	l.readbytes = offset
+	l.offset = offset
+	l.progress.last = offset
+}
+
+// NewBytes creates a returns a lexer ready to parse src.
+// Such lexer supports fast scanning with TakeUntilByte.
+func NewBytes(src []byte, start StateFunc, opts ...Option) *L {
+	return NewSource(BytesSource(src), start, opts...)
+}
+
+// NewString creates a returns a lexer ready to parse src.
+// Such lexer supports fast scanning with TakeUntilByte.
+func NewString(src string, start StateFunc, opts ...Option) *L {
+	return NewSource(StringSource(src), start, opts...)
 }
 
 //NextTokens Reads until at least one token is met, it returns nil a []*Token{nil} at EOF.
 func (l *L) NextTokens() []*Token {
-	if l.hasNext == false {
-		l.TokenHandler = func(t Token) {
-			l.lastTokens = append(l.lastTokens, &t)
-		}
-		l.lastTokens = l.lastTokens[:0]
-		l.nextState = l.startState
-		l.hasNext = true
-	}
+	l.startPull()
 	state := l.nextState
-	l.nextState = state(l)
+	l.nextState = l.run(state)
 	if l.nextState == nil {
-		l.lastTokens = l.lastTokens[:0]
+		l.pending, l.next = l.pending[:0], 0
 		l.nextState = l.startState
 		l.hasNext = false
 		return []*Token{nil}
 	}
-	ret := l.lastTokens[:]
-	l.lastTokens = l.lastTokens[:0]
+	ret := make([]*Token, 0, len(l.pending)-l.next)
+	for _, t := range l.pending[l.next:] {
+		t := t
+		ret = append(ret, &t)
+	}
+	l.pending, l.next = l.pending[:0], 0
 	return ret
 }
 
 //NextToken Reads until a token is met, it returns nil at EOF.
+// ReadToken and BatchNext do not allocate the tokens.
 func (l *L) NextToken() *Token {
-	var ret *Token
-	if l.hasNext == false {
-		l.TokenHandler = func(t Token) {
-			l.lastTokens = append(l.lastTokens, &t)
-		}
-		l.lastTokens = l.lastTokens[:0]
-		l.nextState = l.startState
-		l.hasNext = true
+	t, ok := l.pull()
+	if !ok {
+		return nil
 	}
-	if len(l.lastTokens) > 0 {
-		ret = l.lastTokens[0]
-		l.lastTokens = l.lastTokens[1:]
-	} else {
-		for l.nextState != nil {
-			state := l.nextState
-			l.nextState = state(l)
-			if len(l.lastTokens) > 0 {
-				break
-			}
+	return &t
+}
+
+// BatchNext fills dst with up to len(dst) tokens,
+// it returns the number of tokens read, 0 at EOF.
+func (l *L) BatchNext(dst []Token) int {
+	n := 0
+	for n < len(dst) {
+		t, ok := l.pull()
+		if !ok {
+			break
 		}
+		dst[n] = t
+		n++
+	}
+	return n
+}
+
+// ReadToken reads the next token, it returns io.EOF at the end of the input,
+// or the lexer error once the tokens emitted before it were read.
+func (l *L) ReadToken() (Token, error) {
+	if t, ok := l.pull(); ok {
+		return t, nil
+	}
+	if l.Err != nil {
+		return Token{}, l.Err
+	}
+	return Token{}, io.EOF
+}
+
+// pull returns the next token of the pull API, ok is false at EOF.
+func (l *L) pull() (t Token, ok bool) {
+	l.startPull()
+	for l.next == len(l.pending) {
 		if l.nextState == nil {
-			l.lastTokens = append(l.lastTokens, nil)
-		}
-		if len(l.lastTokens) > 0 {
-			ret = l.lastTokens[0]
-			l.lastTokens = l.lastTokens[1:]
+			return t, false
 		}
+		l.pending, l.next = l.pending[:0], 0
+		l.nextState = l.run(l.nextState)
 	}
-	return ret
+	t = l.pending[l.next]
+	l.next++
+	return t, true
 }
 
 //Scan Broweses all tokens and invokdes f for each of them.
-func (l *L) Scan(f func(t Token)) {
+// It returns the errors reported by the lexer, if any, see Errs.
+func (l *L) Scan(f func(t Token)) error {
 	l.TokenHandler = f
 	state := l.startState
 	for state != nil {
-		state = state(l)
+		state = l.run(state)
 	}
+	return l.Errs()
+}
+
+// All lexes the whole source and returns its tokens and errors, see Scan.
+func (l *L) All() ([]Token, error) {
+	var tokens []Token
+	err := l.Scan(func(t Token) {
+		tokens = append(tokens, t)
+	})
+	return tokens, err
+}
+
+// TokenSink receives the tokens of ScanContext,
+// it returns an error to abort the scan.
+type TokenSink func(t Token) error
+
+// ScanContext Browses all tokens and invokes sink for each of them,
+// until ctx is done or sink returns an error.
+// It returns the context or the sink error, otherwise the errors reported
+// by the lexer, if any, see Errs.
+func (l *L) ScanContext(ctx context.Context, sink TokenSink) error {
+	var sinkErr error
+	l.TokenHandler = func(t Token) {
+		if sinkErr == nil {
+			sinkErr = sink(t)
+		}
+	}
+	state := l.startState
+	for state != nil {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		state = l.run(state)
+		if sinkErr != nil {
+			return sinkErr
+		}
+	}
+	return l.Errs()
 }
 
 // Not Helper function
@@ -163,39 +372,227 @@ func Not(t TokenType, f func(Token)) func(Token) {
 	}
 }
 
+// AppendRune appends r to the current value without consuming the input.
+// Once a rune or a string is appended, the value is made of the appended
+// content only, the runes consumed with Next are not part of it.
+func (l *L) AppendRune(r rune) {
+	l.built = true
+	l.value = append(l.value, r)
+}
+
+// AppendString appends s to the current value, see AppendRune.
+func (l *L) AppendString(s string) {
+	l.built = true
+	for _, r := range s {
+		l.value = append(l.value, r)
+	}
+}
+
 // Current returns the value being analyzed at this moment.
+// It is a substring of the input of StringSource lexers, without copy.
 func (l *L) Current() string {
+	if l.built {
+		return string(l.value)
+	}
+	if s, ok := l.src.(slicer); ok && l.wrapped.next == nil && l.replay == nil {
+		if v, ok := l.slice(s); ok {
+			return v
+		}
+	}
 	return string(l.buf[l.start:l.position])
 }
 
+// slice returns the current value from the input of s,
+// ok is false if its offsets are unknown.
+func (l *L) slice(s slicer) (v string, ok bool) {
+	to := l.src.Offset()
+	for _, r := range l.buf[l.position:] {
+		if r == utf8.RuneError {
+			return "", false
+		}
+		to -= int64(utf8.RuneLen(r))
+	}
+	from := to
+	for _, r := range l.buf[l.start:l.position] {
+		if r == utf8.RuneError {
+			return "", false
+		}
+		from -= int64(utf8.RuneLen(r))
+	}
+	return s.slice(from, to), true
+}
+
 // Emit will receive a token type and push a new token with the current analyzed
 // value into the tokens channel.
 func (l *L) Emit(t TokenType) {
+	if l.lazy != nil {
+		l.emit(t, "")
+		return
+	}
+	l.emit(t, l.Current())
+}
+
+// Keywords maps keywords to their token type.
+type Keywords map[string]TokenType
+
+// EmitKeywordOr emits the current value with its keyword type,
+// or with t if it is not a keyword.
+func (l *L) EmitKeywordOr(keywords Keywords, t TokenType) {
+	if k, ok := keywords[l.Current()]; ok {
+		t = k
+	}
+	l.Emit(t)
+}
+
+// EmitKeywordFoldOr is like EmitKeywordOr, but it matches the keywords
+// ignoring case, their keys must be lower case. See UnicodeFold.
+// If normalize is true, the value of the emitted keyword is its key.
+func (l *L) EmitKeywordFoldOr(keywords Keywords, t TokenType, normalize bool) {
+	value := l.Current()
+	key := l.lowerFold(value)
+	if k, ok := keywords[key]; ok {
+		t = k
+		if normalize {
+			value = key
+		}
+	}
+	l.emit(t, value)
+}
+
+func (l *L) emit(t TokenType, value string) {
+	if l.wrapped.emit != nil {
+		l.wrapped.emit(t, value)
+		return
+	}
+	l.emitToken(t, value)
+}
+
+func (l *L) emitToken(t TokenType, value string) {
 	tok := Token{
-		Type:  t,
-		Value: l.Current(),
+		Type:   t,
+		Value:  value,
+		Line:   l.line,
+		Col:    l.col,
+		Offset: l.offset,
+		Index:  l.index,
+		File:   l.file,
+	}
+	l.index++
+	if l.sourceMap != nil {
+		l.sourceMap.locate(&tok)
+	}
+	if l.Intern != nil {
+		tok.Value = l.Intern.Intern(tok.Value)
+	}
+	if l.Provenance {
+		tok.State = l.stateName
+	}
+	l.dispatch(tok)
+	l.advance()
+	if l.lazy != nil {
+		l.lazy.Tokens = append(l.lazy.Tokens, LazyToken{Type: t, Start: tok.Offset, End: l.offset, Line: tok.Line, Col: tok.Col})
+	}
+	l.drop()
+	l.rewind.clear()
+}
+
+// InsertToken emits the synthetic token t without consuming the current value,
+// such as an implicit semicolon or a recovery token. Its Index is set, and it is
+// located at the start of the current value if its Line is not set.
+// It goes through the hooks and the TokenHandler, not through the Emit middlewares.
+func (l *L) InsertToken(t Token) {
+	if t.Line == 0 {
+		t.Line, t.Col, t.Offset, t.File = l.line, l.col, l.offset, l.file
+		if l.sourceMap != nil {
+			l.sourceMap.locate(&t)
+		}
+	}
+	t.Index = l.index
+	l.index++
+	if l.Provenance && t.State == "" {
+		t.State = l.stateName
+	}
+	l.dispatch(t)
+}
+
+// dispatch delivers tok to the recorder, the hooks and the TokenHandler,
+// it is held during a transaction, see Begin.
+func (l *L) dispatch(tok Token) {
+	if len(l.transactions) > 0 {
+		tx := &l.transactions[len(l.transactions)-1]
+		tx.tokens = append(tx.tokens, tok)
+		return
+	}
+	if l.recorder != nil {
+		l.recorder.token(tok)
+	}
+	if l.replay != nil {
+		l.replayed(RecordEvent{Token: (*plainToken)(&tok)})
+	}
+	for _, f := range l.hooks.emit {
+		f(tok)
 	}
 	if l.TokenHandler != nil {
 		l.TokenHandler(tok)
 	}
 	// l.tokens <- tok
-	l.buf = l.buf[l.position:]
-	l.start = 0
-	l.position = 0
-	l.rewind.clear()
 }
 
 // Ignore clears the rewind stack and then sets the current beginning position
 // to the current position in the source which effectively ignores the section
 // of the source being analyzed.
+// In the significant newline mode, the newlines are emitted,
+// in the lossless mode the whole value is emitted.
 func (l *L) Ignore() {
+	if l.lossless {
+		if l.position > l.start {
+			l.Emit(l.skipped)
+		}
+		return
+	}
 	l.rewind.clear()
-	l.buf = l.buf[l.position:]
+	if l.newlines != nil {
+		l.ignoreNewlines()
+		return
+	}
+	l.advance()
+	l.drop()
+}
+
+// compactSize is the size of the values above which the buffers are compacted.
+const compactSize = 4096
+
+// drop discards the current value, the lookahead is moved to the start
+// of the buffer to reuse its storage.
+func (l *L) drop() {
+	l.dropped += l.position
+	if l.position > compactSize {
+		l.buf = append([]rune(nil), l.buf[l.position:]...)
+	} else {
+		l.buf = l.buf[:copy(l.buf, l.buf[l.position:])]
+	}
 	l.start = 0
 	l.position = 0
+	l.built, l.value = false, l.value[:0]
 }
 
-// ReadBytes returns number of byte reead.
+// Compact releases the memory retained by the buffers after large values.
+// It is done automatically when a value larger than 4096 runes is emitted or ignored.
+func (l *L) Compact() {
+	l.buf = append([]rune(nil), l.buf...)
+	l.lineText = append([]rune(nil), l.lineText...)
+}
+
+// OnProgress registers cb to be invoked each time at least every bytes
+// were read from the source since the last invocation.
+func (l *L) OnProgress(every int, cb func(bytesRead int64)) {
+	if every < 1 {
+		every = 1
+	}
+	l.progress = progress{every: every, last: l.readbytes, cb: cb}
+}
+
+// ReadBytes returns number of byte reead, see SetBaseOffset.
 func (l *L) ReadBytes() int {
 	return l.readbytes
 }
@@ -214,37 +611,57 @@ func (l *L) Peek() rune {
 func (l *L) Rewind() {
 	r := l.rewind.pop()
 	if r > EOFRune {
-		size := utf8.RuneLen(r)
-		l.position -= size
+		l.position--
 		if l.position < l.start {
 			l.position = l.start
 		}
 	}
 }
 
+// AbortToken discards the current value, its runes are returned to the unread
+// input, so a state can back out of a speculative token. Unlike Ignore,
+// the runes are read again by the next calls to Next.
+func (l *L) AbortToken() {
+	l.position = l.start
+	l.rewind.clear()
+	l.built, l.value = false, l.value[:0]
+}
+
 // Next pulls the next rune from the Lexer and returns it, moving the position
 // forward in the source.
 func (l *L) Next() rune {
-	var (
-		r rune
-		s int
-	)
+	if l.wrapped.next != nil {
+		return l.wrapped.next()
+	}
+	return l.nextRune()
+}
+
+func (l *L) nextRune() rune {
+	var r rune
 	if l.position < len(l.buf) {
-		r = l.buf[l.position:][0]
-		l.position += utf8.RuneLen(r)
+		r = l.buf[l.position]
+		l.position++
 		l.rewind.push(r)
 		return r
 	}
 
-	n, _ := l.source.Read(l.p)
-	l.readbytes += n
-	if n == 0 {
-		r, s = EOFRune, 0
-	} else {
-		r, s = utf8.DecodeRune(l.p)
+	if l.MaxBuffer > 0 && len(l.buf) >= l.MaxBuffer {
+		l.bufferExceeded()
+		l.rewind.push(EOFRune)
+		return EOFRune
+	}
+	r = l.read()
+	if r == EOFRune && len(l.includes) > 0 && l.start == len(l.buf) {
+		l.endInclude()
+		return l.nextRune()
+	}
+	if r != EOFRune {
 		l.buf = append(l.buf, r)
+		l.position++
+		if l.recorder != nil {
+			l.recorder.consume(r)
+		}
 	}
-	l.position += s
 	l.rewind.push(r)
 
 	return r
@@ -254,26 +671,353 @@ func (l *L) Next() rune {
 // over each consecutive character in the source until a token not in the given
 // string is encountered. This should be used to quickly pull token parts.
 func (l *L) Take(chars string) {
+	if l.takeChars != chars || !l.takeASCII {
+		l.takeChars = chars
+		l.takeSet, l.takeASCII = makeASCIISet(chars)
+	}
 	r := l.Next()
-	for strings.ContainsRune(chars, r) {
+	if l.takeASCII {
+		for l.takeSet.contains(r) {
+			r = l.Next()
+		}
+	} else {
+		for strings.ContainsRune(chars, r) {
+			r = l.Next()
+		}
+	}
+	l.Rewind() // last next wasn't a match
+}
+
+// AcceptRange consumes the next rune if it is between lo and hi, included,
+// and reports whether it did.
+func (l *L) AcceptRange(lo, hi rune) bool {
+	if r := l.Next(); r != EOFRune && r >= lo && r <= hi {
+		return true
+	}
+	l.Rewind()
+	return false
+}
+
+// TakeRange consumes the runes between lo and hi, included.
+func (l *L) TakeRange(lo, hi rune) {
+	r := l.Next()
+	for r != EOFRune && r >= lo && r <= hi {
 		r = l.Next()
 	}
 	l.Rewind() // last next wasn't a match
 }
 
+// TakeUntilByte consumes the source until the ASCII character b,
+// or until EOF. It searches b with bytes.IndexByte for the lexers
+// of BytesSource and StringSource.
+func (l *L) TakeUntilByte(b byte) {
+	c := rune(b)
+	for l.position < len(l.buf) {
+		if r := l.Next(); r == c {
+			l.Rewind()
+			return
+		}
+	}
+	bi, ok := l.src.(byteIndexer)
+	if !ok || l.wrapped.next != nil || l.MaxBuffer > 0 {
+		r := l.Next()
+		for r != EOFRune && r != c {
+			r = l.Next()
+		}
+		l.Rewind() // last next wasn't a match
+		return
+	}
+	for n := bi.indexByte(b); n > 0; {
+		r, size, _ := l.src.ReadRune()
+		n -= size
+		l.readbytes += size
+		l.measure(r, size)
+		l.buf = append(l.buf, r)
+		l.position++
+		l.rewind.push(r)
+		if l.recorder != nil {
+			l.recorder.consume(r)
+		}
+	}
+	l.notifyProgress()
+}
+
+// TakeFold is like Take, but it matches chars ignoring case.
+// See UnicodeFold.
+func (l *L) TakeFold(chars string) {
+	r := l.Next()
+	for r != EOFRune && strings.IndexFunc(chars, func(c rune) bool { return l.equalFold(c, r) }) > -1 {
+		r = l.Next()
+	}
+	l.Rewind() // last next wasn't a match
+}
+
+// AcceptString consumes s if the source continues with it,
+// otherwise it rewinds and returns false.
+func (l *L) AcceptString(s string) bool {
+	return l.acceptString(s, func(a, b rune) bool { return a == b })
+}
+
+// TakeLongest consumes and emits the longest of ops matching at the current
+// position, with the type of the same index in types.
+// It returns false if none matches. See OperatorSet for large sets of ops.
+func (l *L) TakeLongest(ops []string, types []TokenType) bool {
+	best, size := -1, 0
+	for i, op := range ops {
+		n := utf8.RuneCountInString(op)
+		if n > size && l.AcceptString(op) {
+			best, size = i, n
+			for ; n > 0; n-- {
+				l.Rewind()
+			}
+		}
+	}
+	if best < 0 {
+		return false
+	}
+	l.AcceptString(ops[best])
+	l.Emit(types[best])
+	return true
+}
+
+// AcceptStringFold is like AcceptString, but it matches s ignoring case.
+// See UnicodeFold.
+func (l *L) AcceptStringFold(s string) bool {
+	return l.acceptString(s, l.equalFold)
+}
+
+// Error reports the error e located at the start of the current value.
+// It panics if ErrorHandler is not set.
+//
+// Once MaxErrors errors were reported, it reports a final
+// "too many errors" error and the lexing stops.
 func (l *L) Error(e string) {
+	if l.wrapped.err != nil {
+		l.wrapped.err(e)
+		return
+	}
+	l.reportError(e)
+}
+
+func (l *L) reportError(e string) {
+	if l.MaxErrors > 0 && l.errors >= l.MaxErrors {
+		return
+	}
 	if l.ErrorHandler != nil {
-		l.Err = errors.New(e)
+		l.errors++
+		l.Err = l.report(l.lexError(e))
 		l.ErrorHandler(e)
+		if l.MaxErrors > 0 && l.errors >= l.MaxErrors {
+			l.Err = l.report(l.lexError("too many errors"))
+			l.ErrorHandler(l.Err.Error())
+		}
 	} else {
 		panic(e)
 	}
 }
 
+// Warnf reports a warning located at the start of the current value,
+// it does not set Err.
+func (l *L) Warnf(format string, args ...interface{}) {
+	l.notice(SeverityWarning, fmt.Sprintf(format, args...))
+}
+
+// Infof reports an info located at the start of the current value,
+// it does not set Err.
+func (l *L) Infof(format string, args ...interface{}) {
+	l.notice(SeverityInfo, fmt.Sprintf(format, args...))
+}
+
 // // Private methods
+func (l *L) notice(severity Severity, msg string) {
+	e := l.lexError(msg)
+	e.Severity = severity
+	l.report(e)
+	if l.WarningHandler != nil {
+		l.WarningHandler(e)
+	}
+}
+
+// report collects e into the diagnostics.
+func (l *L) report(e *LexError) *LexError {
+	l.diagnostics = append(l.diagnostics, e)
+	for _, f := range l.hooks.err {
+		f(e)
+	}
+	return e
+}
+
+func (l *L) acceptString(s string, equal func(a, b rune) bool) bool {
+	n := 0
+	for _, c := range s {
+		n++
+		if r := l.Next(); r == EOFRune || !equal(c, r) {
+			for ; n > 0; n-- {
+				l.Rewind()
+			}
+			return false
+		}
+	}
+	return true
+}
+
+func (l *L) lowerFold(s string) string {
+	if l.UnicodeFold {
+		return strings.ToLower(s)
+	}
+	return strings.Map(func(r rune) rune {
+		if 'A' <= r && r <= 'Z' {
+			r += 'a' - 'A'
+		}
+		return r
+	}, s)
+}
+
+func (l *L) equalFold(a, b rune) bool {
+	if a == b {
+		return true
+	}
+	if l.UnicodeFold {
+		for f := unicode.SimpleFold(a); f != a; f = unicode.SimpleFold(f) {
+			if f == b {
+				return true
+			}
+		}
+		return false
+	}
+	if 'A' <= a && a <= 'Z' {
+		a += 'a' - 'A'
+	}
+	if 'A' <= b && b <= 'Z' {
+		b += 'a' - 'A'
+	}
+	return a == b && a < utf8.RuneSelf
+}
+
+func (l *L) startPull() {
+	if l.hasNext == false {
+		l.TokenHandler = l.queue
+		l.pending, l.next = l.pending[:0], 0
+		l.nextState = l.startState
+		l.hasNext = true
+	}
+}
+
+// queue is the TokenHandler of the pull API.
+func (l *L) queue(t Token) {
+	l.pending = append(l.pending, t)
+}
+
+// advance moves the line and column counters over the current value.
+func (l *L) advance() {
+	for i, r := range l.buf[l.start:l.position] {
+		l.offset += l.runeSize(l.start+i, r)
+		if r == '\n' {
+			l.line++
+			l.col = 1
+			if cap(l.lineText) > compactSize {
+				l.lineText = nil
+			} else {
+				l.lineText = l.lineText[:0]
+			}
+		} else {
+			l.col += l.colWidth(l.start+i, r)
+			l.lineText = append(l.lineText, r)
+		}
+	}
+	for len(l.widths) > 0 && l.widths[0].index < l.dropped+l.position {
+		l.widths = l.widths[1:]
+	}
+}
+
+func (l *L) lexError(msg string) *LexError {
+	text := append([]rune(nil), l.lineText...)
+	for _, r := range l.buf[l.start:] {
+		if r == '\n' {
+			break
+		}
+		text = append(text, r)
+	}
+	e := &LexError{Msg: msg, Line: l.line, Col: l.col, LineText: string(text), Columns: l.Columns, File: l.file}
+	e.EndLine, e.EndCol = e.Line, e.Col
+	for i, r := range l.buf[l.start:l.position] {
+		if r == '\n' {
+			e.EndLine++
+			e.EndCol = 1
+		} else {
+			e.EndCol += l.colWidth(l.start+i, r)
+		}
+	}
+	if l.sourceMap != nil {
+		to := l.offset
+		for i, r := range l.buf[l.start:l.position] {
+			to += l.runeSize(l.start+i, r)
+		}
+		l.sourceMap.locateError(e, l.offset, to)
+	}
+	return e
+}
+
+func (l *L) read() rune {
+	r, size, err := l.src.ReadRune()
+	if err == ErrNeedInput && l.feeding {
+		panic(needInput{})
+	}
+	l.readbytes += size
+	l.notifyProgress()
+	if err != nil || size == 0 {
+		return EOFRune
+	}
+	l.measure(r, size)
+	return r
+}
+
+// runeWidth is the size in the source of the buffered rune at index,
+// counted from the start of the input.
+type runeWidth struct {
+	index, size int
+}
+
+// measure records the size of r if it differs from its encoding, such as
+// invalid bytes or normalized newlines, to keep the offsets exact.
+// r is the next rune appended to buf.
+func (l *L) measure(r rune, size int) {
+	if size != utf8.RuneLen(r) {
+		l.widths = append(l.widths, runeWidth{index: l.dropped + len(l.buf), size: size})
+	}
+}
+
+// runeSize returns the size in the source of the rune r at index i of buf.
+func (l *L) runeSize(i int, r rune) int {
+	for _, w := range l.widths {
+		if w.index == l.dropped+i {
+			return w.size
+		} else if w.index > l.dropped+i {
+			break
+		}
+	}
+	return utf8.RuneLen(r)
+}
+
+// bufferExceeded reports the MaxBuffer error once, the lexing then stops.
+func (l *L) bufferExceeded() {
+	if !l.overflow {
+		l.overflow = true
+		l.Error(fmt.Sprintf("buffer limit of %v runes exceeded", l.MaxBuffer))
+	}
+}
+
+func (l *L) notifyProgress() {
+	if l.progress.cb == nil || l.readbytes-l.progress.last < l.progress.every {
+		return
+	}
+	l.progress.last = l.readbytes
+	l.progress.cb(int64(l.readbytes))
+}
+
 func (l *L) scanOnce(f func(t Token)) {
 	l.TokenHandler = f
 	if l.startState != nil {
-		l.startState = l.startState(l)
+		l.startState = l.run(l.startState)
 	}
 }
@@ -2,8 +2,14 @@ package lexer
 
 import (
 	"bytes"
+	"context"
+	"errors"
 	"fmt"
+	"io"
+	"strings"
 	"testing"
+	"unicode"
+	"unsafe"
 )
 
 const (
@@ -12,19 +18,19 @@ const (
 	IdentToken
 )
 
-func NumberState(l *L) StateFunc {
+func numberState(l *L) StateFunc {
 	l.Take("0123456789")
 	l.Emit(NumberToken)
 	if l.Peek() == '.' {
 		l.Next()
 		l.Emit(OpToken)
-		return IdentState
+		return identState
 	}
 
 	return nil
 }
 
-func IdentState(l *L) StateFunc {
+func identState(l *L) StateFunc {
 	r := l.Next()
 	for (r >= 'a' && r <= 'z') || r == '_' {
 		r = l.Next()
@@ -32,10 +38,10 @@ func IdentState(l *L) StateFunc {
 	l.Rewind()
 	l.Emit(IdentToken)
 
-	return WhitespaceState
+	return whitespaceState
 }
 
-func WhitespaceState(l *L) StateFunc {
+func whitespaceState(l *L) StateFunc {
 	r := l.Next()
 	if r == EOFRune {
 		return nil
@@ -49,7 +55,7 @@ func WhitespaceState(l *L) StateFunc {
 	l.Take(" \t\n\r")
 	l.Ignore()
 
-	return NumberState
+	return numberState
 }
 
 func Test_LexerMovingThroughString(t *testing.T) {
@@ -81,7 +87,7 @@ func Test_LexerMovingThroughString(t *testing.T) {
 
 func Test_LexingNumbers(t *testing.T) {
 	b := bytes.NewBufferString("123")
-	l := New(b, NumberState)
+	l := New(b, numberState)
 	var token *Token
 	l.scanOnce(func(tok Token) {
 		token = &tok
@@ -143,7 +149,7 @@ func Test_MultipleTokens(t *testing.T) {
 	}
 
 	b := bytes.NewBufferString("123.hello  675.world")
-	l := New(b, NumberState)
+	l := New(b, numberState)
 
 	var tokens []Token
 	l.Scan(func(tok Token) {
@@ -163,13 +169,27 @@ func Test_MultipleTokens(t *testing.T) {
 	}
 }
 
+func Test_LexerErrors(t *testing.T) {
+	state := func(l *L) StateFunc {
+		l.Error("first")
+		l.Error("second")
+		return nil
+	}
+	l := NewString("", state, WithErrorHandler(func(string) {}))
+	_, err := l.All()
+	if fmt.Sprint(err) != "first\nsecond" {
+		t.Errorf("Expected %q but got %q", "first\nsecond", fmt.Sprint(err))
+		return
+	}
+}
+
 func Test_LexerError(t *testing.T) {
 	b := bytes.NewBufferString("1")
-	l := New(b, WhitespaceState)
+	l := New(b, whitespaceState)
 	l.ErrorHandler = func(e string) {}
 
 	var token *Token
-	l.Scan(func(tok Token) {
+	err := l.Scan(func(tok Token) {
 		token = &tok
 	})
 
@@ -178,6 +198,11 @@ func Test_LexerError(t *testing.T) {
 		return
 	}
 
+	if !errors.Is(err, l.Err) {
+		t.Errorf("Expected Scan to return %v, but got %v", l.Err, err)
+		return
+	}
+
 	if l.Err == nil {
 		t.Error("Expected an error to be on the lexer, but none found.")
 		return
@@ -191,7 +216,7 @@ func Test_LexerError(t *testing.T) {
 
 func Example_lexer() {
 	b := bytes.NewBufferString("1 2 ")
-	l := New(b, NumberState)
+	l := New(b, numberState)
 	l.ErrorHandler = func(e string) {}
 
 	var tokens []Token
@@ -201,5 +226,502 @@ func Example_lexer() {
 
 	fmt.Printf("%#v", tokens)
 	//Output:
-	//[]lexer.Token{lexer.Token{Type:0, Value:"1"}}
+	//[]lexer.Token{lexer.Token{Type:0, Value:"1", Line:1, Col:1, Offset:0, Index:0, State:"", File:""}}
+}
+
+func Test_LexerProgress(t *testing.T) {
+	b := bytes.NewBufferString("123.hello  675.world")
+	l := New(b, numberState)
+
+	var reports []int64
+	l.OnProgress(5, func(n int64) {
+		reports = append(reports, n)
+	})
+	l.Scan(func(tok Token) {})
+
+	expected := []int64{5, 10, 15, 20}
+	if fmt.Sprint(reports) != fmt.Sprint(expected) {
+		t.Errorf("Expected progress reports %v but got %v", expected, reports)
+		return
+	}
+}
+
+func Test_LexerRuneReader(t *testing.T) {
+	l := New(strings.NewReader("héllo"), nil)
+
+	for _, want := range "héllo" {
+		if r := l.Next(); r != want {
+			t.Errorf("Expected %q but got %q", want, r)
+			return
+		}
+	}
+	l.Rewind()
+	if l.Current() != "héll" {
+		t.Errorf("Expected %q but got %q", "héll", l.Current())
+		return
+	}
+	if l.ReadBytes() != len("héllo") {
+		t.Errorf("Expected %v bytes read but got %v", len("héllo"), l.ReadBytes())
+		return
+	}
+}
+
+func Test_TokenPosition(t *testing.T) {
+	b := bytes.NewBufferString("12.ab\n  3.c")
+	l := New(b, numberState)
+
+	var tokens []Token
+	l.Scan(func(tok Token) {
+		tokens = append(tokens, tok)
+	})
+
+	expected := []Token{
+		{Type: NumberToken, Value: "12", Line: 1, Col: 1, Offset: 0, Index: 0},
+		{Type: OpToken, Value: ".", Line: 1, Col: 3, Offset: 2, Index: 1},
+		{Type: IdentToken, Value: "ab", Line: 1, Col: 4, Offset: 3, Index: 2},
+		{Type: NumberToken, Value: "3", Line: 2, Col: 3, Offset: 8, Index: 3},
+		{Type: OpToken, Value: ".", Line: 2, Col: 4, Offset: 9, Index: 4},
+		{Type: IdentToken, Value: "c", Line: 2, Col: 5, Offset: 10, Index: 5},
+	}
+	if fmt.Sprint(tokens) != fmt.Sprint(expected) {
+		t.Errorf("Expected %v but got %v", expected, tokens)
+		return
+	}
+}
+
+func Test_TokenText(t *testing.T) {
+	tok := Token{Type: IdentToken, Value: "a:b@c\n", Line: 3, Col: 7}
+	b, err := tok.MarshalText()
+	if err != nil {
+		t.Errorf("Unexpected error %v", err)
+		return
+	}
+	if string(b) != `2:"a:b@c\n"@3:7` {
+		t.Errorf("Expected %q but got %q", `2:"a:b@c\n"@3:7`, string(b))
+		return
+	}
+
+	var got Token
+	if err := got.UnmarshalText(b); err != nil {
+		t.Errorf("Unexpected error %v", err)
+		return
+	}
+	if got != tok {
+		t.Errorf("Expected %v but got %v", tok, got)
+		return
+	}
+
+	if err := got.UnmarshalText([]byte("2:abc")); err == nil {
+		t.Error("Expected an error, but none found.")
+		return
+	}
+}
+
+func Test_ReadToken(t *testing.T) {
+	b := bytes.NewBufferString("123.hello,world")
+	l := New(b, numberState)
+	l.ErrorHandler = func(e string) {}
+
+	var tokens []Token
+	var err error
+	for {
+		var tok Token
+		tok, err = l.ReadToken()
+		if err != nil {
+			break
+		}
+		tokens = append(tokens, tok)
+	}
+
+	if len(tokens) != 3 {
+		t.Errorf("Expected %v tokens but got %v", 3, len(tokens))
+		return
+	}
+	if err == nil || err.Error() != "unexpected token ','" {
+		t.Errorf("Expected specific error, but got %v", err)
+		return
+	}
+
+	l = New(bytes.NewBufferString("123"), numberState)
+	if _, err := l.ReadToken(); err != nil {
+		t.Errorf("Unexpected error %v", err)
+		return
+	}
+	if _, err := l.ReadToken(); err != io.EOF {
+		t.Errorf("Expected %v but got %v", io.EOF, err)
+		return
+	}
+}
+
+func Test_ScanContext(t *testing.T) {
+	b := bytes.NewBufferString("123.hello  675.world")
+	l := New(b, numberState)
+
+	stop := errors.New("stop")
+	var tokens []Token
+	err := l.ScanContext(context.Background(), func(tok Token) error {
+		tokens = append(tokens, tok)
+		if len(tokens) == 2 {
+			return stop
+		}
+		return nil
+	})
+	if err != stop {
+		t.Errorf("Expected %v but got %v", stop, err)
+		return
+	}
+	if len(tokens) != 2 {
+		t.Errorf("Expected %v tokens but got %v", 2, len(tokens))
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	l = New(bytes.NewBufferString("123"), numberState)
+	err = l.ScanContext(ctx, func(tok Token) error {
+		t.Errorf("Unexpected token %v", tok)
+		return nil
+	})
+	if err != context.Canceled {
+		t.Errorf("Expected %v but got %v", context.Canceled, err)
+		return
+	}
+}
+
+func Test_BatchNext(t *testing.T) {
+	b := bytes.NewBufferString("123.hello  675.world")
+	l := New(b, numberState)
+
+	var tokens []Token
+	dst := make([]Token, 4)
+	for n := l.BatchNext(dst); n > 0; n = l.BatchNext(dst) {
+		tokens = append(tokens, dst[:n]...)
+	}
+
+	expected := []string{"123", ".", "hello", "675", ".", "world"}
+	if len(tokens) != len(expected) {
+		t.Errorf("Expected %v tokens but got %v", len(expected), len(tokens))
+		return
+	}
+	for i, v := range expected {
+		if tokens[i].Value != v {
+			t.Errorf("Expected %q but got %q", v, tokens[i].Value)
+			return
+		}
+	}
+}
+
+func Test_All(t *testing.T) {
+	l := New(bytes.NewBufferString("123.hello  675.world"), numberState)
+	tokens, err := l.All()
+	if err != nil {
+		t.Errorf("Unexpected error %v", err)
+		return
+	}
+	if len(tokens) != 6 {
+		t.Errorf("Expected %v tokens but got %v", 6, len(tokens))
+		return
+	}
+}
+
+func Test_LexerIntern(t *testing.T) {
+	l := New(bytes.NewBufferString("1.a 1.a 1.a"), numberState)
+	l.Intern = NewInternTable()
+	tokens, err := l.All()
+	if err != nil {
+		t.Errorf("Unexpected error %v", err)
+		return
+	}
+	if len(tokens) != 9 {
+		t.Errorf("Expected %v tokens but got %v", 9, len(tokens))
+		return
+	}
+	if l.Intern.Len() != 3 {
+		t.Errorf("Expected %v interned values but got %v", 3, l.Intern.Len())
+		return
+	}
+	if unsafe.StringData(tokens[0].Value) != unsafe.StringData(tokens[6].Value) {
+		t.Error("Expected the token values to share the same instance")
+		return
+	}
+}
+
+func Test_LexerInternZero(t *testing.T) {
+	var table InternTable
+	l := New(bytes.NewBufferString("1.a 1.a"), numberState, WithIntern(&table))
+	if _, err := l.All(); err != nil || table.Len() != 3 {
+		t.Errorf("Expected %v interned values but got %v %v", 3, table.Len(), err)
+		return
+	}
+}
+
+func Test_LexerInternCopy(t *testing.T) {
+	src := "abc"
+	table := NewInternTable()
+	l := NewString(src, identState, WithIntern(table))
+	tokens, _ := l.All()
+	if len(tokens) != 1 || unsafe.StringData(tokens[0].Value) == unsafe.StringData(src) {
+		t.Errorf("Expected a copy of %q but got %v", src, tokens)
+		return
+	}
+}
+
+func Test_LexerFold(t *testing.T) {
+	l := New(bytes.NewBufferString("SeLect aBc1"), nil)
+	if l.AcceptStringFold("selectx") {
+		t.Error("Expected no match")
+		return
+	}
+	if l.Current() != "" {
+		t.Errorf("Expected empty string, but got %q", l.Current())
+		return
+	}
+	if !l.AcceptStringFold("select") {
+		t.Error("Expected a match")
+		return
+	}
+	if l.Current() != "SeLect" {
+		t.Errorf("Expected %q but got %q", "SeLect", l.Current())
+		return
+	}
+	l.Take(" ")
+	l.Ignore()
+	l.TakeFold("abc")
+	if l.Current() != "aBc" {
+		t.Errorf("Expected %q but got %q", "aBc", l.Current())
+		return
+	}
+
+	l = New(bytes.NewBufferString("ÉTÉ"), nil)
+	if l.AcceptStringFold("été") {
+		t.Error("Expected no match without UnicodeFold")
+		return
+	}
+	l.UnicodeFold = true
+	if !l.AcceptStringFold("été") {
+		t.Error("Expected a match with UnicodeFold")
+		return
+	}
+}
+
+func Test_LexerKeywords(t *testing.T) {
+	const (
+		SelectToken TokenType = iota + 10
+		FromToken
+	)
+	keywords := Keywords{"select": SelectToken, "from": FromToken}
+	state := func(l *L) StateFunc {
+		l.Take(" ")
+		l.Ignore()
+		l.TakeFold("abcdefghijklmnopqrstuvwxyz")
+		if l.Current() == "" {
+			return nil
+		}
+		l.EmitKeywordFoldOr(keywords, IdentToken, true)
+		return l.startState
+	}
+
+	tokens, err := New(bytes.NewBufferString("SELECT Name frOm"), state).All()
+	if err != nil {
+		t.Errorf("Unexpected error %v", err)
+		return
+	}
+	expected := []Token{
+		{Type: SelectToken, Value: "select", Line: 1, Col: 1, Offset: 0, Index: 0},
+		{Type: IdentToken, Value: "Name", Line: 1, Col: 8, Offset: 7, Index: 1},
+		{Type: FromToken, Value: "from", Line: 1, Col: 13, Offset: 12, Index: 2},
+	}
+	if fmt.Sprint(tokens) != fmt.Sprint(expected) {
+		t.Errorf("Expected %v but got %v", expected, tokens)
+		return
+	}
+}
+
+func Benchmark_Take(b *testing.B) {
+	src := strings.Repeat("0123456789", 1000)
+	for i := 0; i < b.N; i++ {
+		l := New(strings.NewReader(src), nil)
+		l.Take("0123456789")
+	}
+}
+
+func Benchmark_Scan(b *testing.B) {
+	src := strings.Repeat("123.hello\n", 1000)
+	b.ReportAllocs()
+	l := NewString("", numberState)
+	for i := 0; i < b.N; i++ {
+		l.src = StringSource(src)
+		l.Scan(func(Token) {})
+	}
+}
+
+func Benchmark_ReadToken(b *testing.B) {
+	src := strings.Repeat("123.hello\n", 1000)
+	b.ReportAllocs()
+	l := NewString("", numberState)
+	for i := 0; i < b.N; i++ {
+		l.src = StringSource(src)
+		l.hasNext = false
+		for _, err := l.ReadToken(); err == nil; _, err = l.ReadToken() {
+		}
+	}
+}
+
+func Test_TakeRange(t *testing.T) {
+	l := NewString("abz9", nil)
+	if l.AcceptRange('b', 'z') {
+		t.Errorf("Expected %v but got %v", false, true)
+		return
+	}
+	if !l.AcceptRange('a', 'a') {
+		t.Errorf("Expected %v but got %v", true, false)
+		return
+	}
+	l.TakeRange('a', 'z')
+	if l.Current() != "abz" {
+		t.Errorf("Expected %q but got %q", "abz", l.Current())
+		return
+	}
+	l.TakeRange('0', '9')
+	if l.Current() != "abz9" || l.AcceptRange(0, unicode.MaxRune) {
+		t.Errorf("Expected %q at EOF but got %q", "abz9", l.Current())
+		return
+	}
+}
+
+func Test_TakeUntilByte(t *testing.T) {
+	for _, l := range []*L{
+		NewString(`"héllo" world`, nil),
+		New(bytes.NewBufferString(`"héllo" world`), nil),
+	} {
+		l.Next()
+		l.Ignore()
+		l.Peek()
+		l.TakeUntilByte('"')
+		if l.Current() != "héllo" {
+			t.Errorf("Expected %q but got %q", "héllo", l.Current())
+			return
+		}
+		l.Rewind()
+		if l.Current() != "héll" {
+			t.Errorf("Expected %q but got %q", "héll", l.Current())
+			return
+		}
+		l.TakeUntilByte('\n')
+		if l.Current() != `héllo" world` {
+			t.Errorf("Expected %q but got %q", `héllo" world`, l.Current())
+			return
+		}
+		if l.Next() != EOFRune {
+			t.Error("Expected EOF")
+			return
+		}
+		if l.ReadBytes() != len(`"héllo" world`) {
+			t.Errorf("Expected %v bytes read but got %v", len(`"héllo" world`), l.ReadBytes())
+			return
+		}
+	}
+}
+
+func Test_LexerCompact(t *testing.T) {
+	l := NewString(strings.Repeat("a", 5000)+" b", nil)
+	l.TakeUntilByte(' ')
+	l.Emit(IdentToken)
+	if cap(l.buf) > compactSize {
+		t.Errorf("Expected a compacted buffer but its capacity is %v", cap(l.buf))
+		return
+	}
+
+	l.Next()
+	l.Compact()
+	if l.Current() != " " || cap(l.buf) > 8 {
+		t.Errorf("Unexpected value %q of capacity %v", l.Current(), cap(l.buf))
+		return
+	}
+}
+
+func Test_AbortToken(t *testing.T) {
+	l := NewString("12.ab", nil)
+	l.Take("0123456789")
+	l.Emit(NumberToken)
+	l.Take(".abc")
+	l.AbortToken()
+	if l.Current() != "" {
+		t.Errorf("Expected an empty value but got %q", l.Current())
+		return
+	}
+	l.Next()
+	if l.Current() != "." {
+		t.Errorf("Expected %q but got %q", ".", l.Current())
+		return
+	}
+	l.Rewind()
+	l.Rewind()
+	if l.Current() != "" || l.Peek() != '.' {
+		t.Errorf("Unexpected value %q", l.Current())
+		return
+	}
+}
+
+func Test_AppendRune(t *testing.T) {
+	state := func(l *L) StateFunc {
+		l.Next()
+		for r := l.Next(); r != '"' && r != EOFRune; r = l.Next() {
+			if r == '\\' {
+				l.Next()
+				r = '\n'
+			}
+			l.AppendRune(r)
+		}
+		l.Emit(OpToken)
+		l.AppendString("eof")
+		l.Emit(IdentToken)
+		return nil
+	}
+	tokens, _ := NewString(`"a\nb"`, state).All()
+	if len(tokens) != 2 || tokens[0].Value != "a\nb" || tokens[1].Value != "eof" || tokens[1].Col != 7 {
+		t.Errorf("Unexpected tokens %v", tokens)
+		return
+	}
+}
+
+func Test_InsertToken(t *testing.T) {
+	state := func(l *L) StateFunc {
+		l.Take("0123456789")
+		l.InsertToken(Token{Type: OpToken, Value: "+"})
+		l.Emit(NumberToken)
+		l.InsertToken(Token{Type: OpToken, Value: ";", Line: 9, Col: 9})
+		return nil
+	}
+	l := NewString("12", state)
+	var hooked int
+	l.OnEmit(func(Token) { hooked++ })
+	tokens, _ := l.All()
+	expected := []Token{
+		{Type: OpToken, Value: "+", Line: 1, Col: 1, Offset: 0, Index: 0},
+		{Type: NumberToken, Value: "12", Line: 1, Col: 1, Offset: 0, Index: 1},
+		{Type: OpToken, Value: ";", Line: 9, Col: 9, Offset: 0, Index: 2},
+	}
+	if fmt.Sprint(tokens) != fmt.Sprint(expected) || hooked != 3 {
+		t.Errorf("Expected %v but got %v", expected, tokens)
+		return
+	}
+}
+
+func Test_NextGrapheme(t *testing.T) {
+	src := "é\U0001F44D\U0001F3FD\U0001F469\u200d\U0001F4BB\U0001F1EB\U0001F1F7\U0001F1E9\r\n\n"
+	l := NewString(src, nil)
+	var got []string
+	for g := l.NextGrapheme(); g != ""; g = l.NextGrapheme() {
+		got = append(got, g)
+	}
+	expected := []string{"é", "\U0001F44D\U0001F3FD", "\U0001F469\u200d\U0001F4BB", "\U0001F1EB\U0001F1F7", "\U0001F1E9", "\r\n", "\n"}
+	if fmt.Sprintf("%q", got) != fmt.Sprintf("%q", expected) {
+		t.Errorf("Expected %q but got %q", expected, got)
+		return
+	}
+	if l.Current() != src {
+		t.Errorf("Expected %q but got %q", src, l.Current())
+		return
+	}
 }
@@ -0,0 +1,352 @@
+// Package css tokenizes style sheets with the algorithm of the
+// CSS Syntax Module Level 3, section 4.
+//
+// The values of the tokens are their source text, the escapes are not
+// decoded. The comments are dropped, as the specification does, and the
+// newlines are normalized: "\r\n" and "\r" are read as "\n".
+package css
+
+import (
+	"io"
+	"strings"
+
+	lexer "github.com/mh-cbon/state-lexer"
+)
+
+// The token types.
+const (
+	Ident        lexer.TokenType = iota + 1 // color
+	Function                                // rgb(
+	AtKeyword                               // @media
+	Hash                                    // #fff
+	String                                  // "text" or 'text'
+	BadString                               // a string interrupted by a newline
+	URL                                     // url(image.png)
+	BadURL                                  // url(a b)
+	Delim                                   // a single code point, such as > or *
+	Number                                  // 1.5e3
+	Percentage                              // 50%
+	Dimension                               // 12px
+	Whitespace                              // spaces, tabs and newlines
+	CDO                                     // <!--
+	CDC                                     // -->
+	Colon                                   // :
+	Semicolon                               // ;
+	Comma                                   // ,
+	LeftBracket                             // [
+	RightBracket                            // ]
+	LeftParen                               // (
+	RightParen                              // )
+	LeftBrace                               // {
+	RightBrace                              // }
+)
+
+var punctuation = map[rune]lexer.TokenType{
+	':': Colon, ';': Semicolon, ',': Comma,
+	'[': LeftBracket, ']': RightBracket,
+	'(': LeftParen, ')': RightParen,
+	'{': LeftBrace, '}': RightBrace,
+}
+
+// New creates a lexer of the style sheet src.
+func New(src io.Reader) *lexer.L {
+	l := lexer.New(src, Lex)
+	l.NormalizeNewlines()
+	return l
+}
+
+// NewString creates a lexer of the style sheet src.
+func NewString(src string) *lexer.L {
+	l := lexer.NewString(src, Lex)
+	l.NormalizeNewlines()
+	return l
+}
+
+// Lex emits the next token, it reports the parse errors with Error
+// and emits the tokens recovered as the specification defines.
+func Lex(l *lexer.L) lexer.StateFunc {
+	for l.AcceptString("/*") {
+		for !l.AcceptString("*/") {
+			if l.Next() == lexer.EOFRune {
+				l.Error("unterminated comment")
+				break
+			}
+		}
+		l.Ignore()
+	}
+	a, b, c := peek3(l)
+	switch {
+	case a == lexer.EOFRune:
+		return nil
+	case isWhitespace(a):
+		for isWhitespace(l.Peek()) {
+			l.Next()
+		}
+		l.Emit(Whitespace)
+	case a == '"' || a == '\'':
+		lexString(l)
+	case a == '#':
+		l.Next()
+		if isIdent(b) || validEscape(b, c) {
+			takeIdent(l)
+			l.Emit(Hash)
+		} else {
+			l.Emit(Delim)
+		}
+	case startsNumber(a, b, c):
+		lexNumeric(l)
+	case a == '-' && b == '-' && c == '>':
+		l.AcceptString("-->")
+		l.Emit(CDC)
+	case startsIdent(a, b, c):
+		lexIdentLike(l)
+	case a == '<' && l.AcceptString("<!--"):
+		l.Emit(CDO)
+	case a == '@':
+		l.Next()
+		if _, b, c, d := peek4(l); startsIdent(b, c, d) {
+			takeIdent(l)
+			l.Emit(AtKeyword)
+		} else {
+			l.Emit(Delim)
+		}
+	case punctuation[a] != 0:
+		l.Next()
+		l.Emit(punctuation[a])
+	default:
+		if a == '\\' {
+			l.Error("invalid escape")
+		}
+		l.Next()
+		l.Emit(Delim)
+	}
+	return Lex
+}
+
+// lexString emits a quoted string, or a bad string at a newline.
+func lexString(l *lexer.L) {
+	quote := l.Next()
+	for {
+		switch r := l.Next(); r {
+		case quote:
+			l.Emit(String)
+			return
+		case lexer.EOFRune:
+			l.Error("unterminated string")
+			l.Emit(String)
+			return
+		case '\n':
+			l.Rewind()
+			l.Error("newline in string")
+			l.Emit(BadString)
+			return
+		case '\\':
+			if l.Peek() == '\n' {
+				l.Next()
+			} else if l.Peek() != lexer.EOFRune {
+				takeEscape(l)
+			}
+		}
+	}
+}
+
+// lexNumeric emits a number, a percentage or a dimension.
+func lexNumeric(l *lexer.L) {
+	if r := l.Peek(); r == '+' || r == '-' {
+		l.Next()
+	}
+	l.Take("0123456789")
+	if a, b, _ := peek3(l); a == '.' && isDigit(b) {
+		l.Next()
+		l.Take("0123456789")
+	}
+	if a, b, c := peek3(l); (a == 'e' || a == 'E') && (isDigit(b) || (b == '+' || b == '-') && isDigit(c)) {
+		l.Next()
+		l.Next()
+		l.Take("0123456789")
+	}
+	switch a, b, c := peek3(l); {
+	case startsIdent(a, b, c):
+		takeIdent(l)
+		l.Emit(Dimension)
+	case a == '%':
+		l.Next()
+		l.Emit(Percentage)
+	default:
+		l.Emit(Number)
+	}
+}
+
+// lexIdentLike emits an identifier, a function or an url.
+func lexIdentLike(l *lexer.L) {
+	takeIdent(l)
+	if l.Peek() != '(' {
+		l.Emit(Ident)
+		return
+	}
+	l.Next()
+	if !strings.EqualFold(l.Current(), "url(") {
+		l.Emit(Function)
+		return
+	}
+	n := 0
+	for isWhitespace(l.Peek()) {
+		l.Next()
+		n++
+	}
+	if r := l.Peek(); r == '"' || r == '\'' {
+		for ; n > 0; n-- {
+			l.Rewind()
+		}
+		l.Emit(Function)
+		return
+	}
+	lexURL(l)
+}
+
+// lexURL emits the url following url( and its spaces.
+func lexURL(l *lexer.L) {
+	for {
+		switch r := l.Next(); {
+		case r == ')':
+			l.Emit(URL)
+			return
+		case r == lexer.EOFRune:
+			l.Error("unterminated url")
+			l.Emit(URL)
+			return
+		case isWhitespace(r):
+			for isWhitespace(l.Peek()) {
+				l.Next()
+			}
+			if r := l.Peek(); r == ')' || r == lexer.EOFRune {
+				continue
+			}
+			l.Error("invalid url")
+			badURL(l)
+			return
+		case r == '"' || r == '\'' || r == '(' || isNonPrintable(r):
+			l.Error("invalid url")
+			badURL(l)
+			return
+		case r == '\\':
+			if l.Peek() == '\n' {
+				l.Error("invalid escape in url")
+				badURL(l)
+				return
+			}
+			takeEscape(l)
+		}
+	}
+}
+
+// badURL emits the remnants of a bad url.
+func badURL(l *lexer.L) {
+	for {
+		switch r := l.Next(); r {
+		case ')', lexer.EOFRune:
+			l.Emit(BadURL)
+			return
+		case '\\':
+			if l.Peek() != '\n' {
+				takeEscape(l)
+			}
+		}
+	}
+}
+
+// takeIdent consumes an ident sequence.
+func takeIdent(l *lexer.L) {
+	for {
+		a, b, _ := peek3(l)
+		switch {
+		case isIdent(a):
+			l.Next()
+		case validEscape(a, b):
+			l.Next()
+			takeEscape(l)
+		default:
+			return
+		}
+	}
+}
+
+// takeEscape consumes an escape, following its backslash.
+func takeEscape(l *lexer.L) {
+	if !isHex(l.Peek()) {
+		l.Next()
+		return
+	}
+	for i := 0; i < 6 && isHex(l.Peek()); i++ {
+		l.Next()
+	}
+	if isWhitespace(l.Peek()) {
+		l.Next()
+	}
+}
+
+// peek3 returns the next 3 code points, without consuming them.
+func peek3(l *lexer.L) (a, b, c rune) {
+	a, b, c = l.Next(), l.Next(), l.Next()
+	l.Rewind()
+	l.Rewind()
+	l.Rewind()
+	return a, b, c
+}
+
+// peek4 returns the next 4 code points, without consuming them.
+func peek4(l *lexer.L) (a, b, c, d rune) {
+	a, b, c, d = l.Next(), l.Next(), l.Next(), l.Next()
+	for i := 0; i < 4; i++ {
+		l.Rewind()
+	}
+	return a, b, c, d
+}
+
+func startsIdent(a, b, c rune) bool {
+	switch {
+	case a == '-':
+		return isIdentStart(b) || b == '-' || validEscape(b, c)
+	case a == '\\':
+		return validEscape(a, b)
+	}
+	return isIdentStart(a)
+}
+
+func startsNumber(a, b, c rune) bool {
+	switch a {
+	case '+', '-':
+		return isDigit(b) || b == '.' && isDigit(c)
+	case '.':
+		return isDigit(b)
+	}
+	return isDigit(a)
+}
+
+func validEscape(a, b rune) bool {
+	return a == '\\' && b != '\n'
+}
+
+func isIdentStart(r rune) bool {
+	return r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r == '_' || r >= 0x80
+}
+
+func isIdent(r rune) bool {
+	return isIdentStart(r) || isDigit(r) || r == '-'
+}
+
+func isDigit(r rune) bool {
+	return r >= '0' && r <= '9'
+}
+
+func isHex(r rune) bool {
+	return lexer.HexDigits.Contains(r)
+}
+
+func isWhitespace(r rune) bool {
+	return r == ' ' || r == '\t' || r == '\n' || r == '\f'
+}
+
+func isNonPrintable(r rune) bool {
+	return r >= 0 && r <= 8 || r == 0xb || r >= 0xe && r <= 0x1f || r == 0x7f
+}
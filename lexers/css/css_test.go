@@ -0,0 +1,51 @@
+package css
+
+import (
+	"fmt"
+	"testing"
+
+	lexer "github.com/mh-cbon/state-lexer"
+)
+
+func Test_Lex(t *testing.T) {
+	src := "@media (min-width:10px){a#x>.b\\:c/* c */{color:rgb(1 2 3);b:url( i.png ) url('j');w:-5.5e1%;--v:+.5em}}<!-- -->"
+	l := NewString(src)
+	var got []string
+	l.Scan(func(tok lexer.Token) {
+		if tok.Type != Whitespace {
+			got = append(got, fmt.Sprintf("%v:%v", tok.Type, tok.Value))
+		}
+	})
+	expected := "[3:@media 21:( 1:min-width 16:: 12:10px 22:) 23:{ 1:a 4:#x 9:> 9:. 1:b\\:c 23:{ " +
+		"1:color 16:: 2:rgb( 10:1 10:2 10:3 22:) 17:; 1:b 16:: 7:url( i.png ) 2:url( 5:'j' 22:) 17:; " +
+		"1:w 16:: 11:-5.5e1% 17:; 1:--v 16:: 12:+.5em 24:} 24:} 14:<!-- 15:-->]"
+	if fmt.Sprint(got) != expected {
+		t.Errorf("Expected %v but got %v", expected, got)
+		return
+	}
+}
+
+func Test_LexErrors(t *testing.T) {
+	for _, c := range []struct {
+		src, tokens, errs string
+	}{
+		{"'a\nb", "[6:'a 13:\n 1:b]", "[newline in string]"},
+		{"url(a b)c", "[8:url(a b) 1:c]", "[invalid url]"},
+		{"\"a", "[5:\"a]", "[unterminated string]"},
+		{"a/* b", "[1:a]", "[unterminated comment]"},
+	} {
+		l := NewString(c.src)
+		var errs []string
+		l.ErrorHandler = func(e string) {
+			errs = append(errs, e)
+		}
+		var got []string
+		l.Scan(func(tok lexer.Token) {
+			got = append(got, fmt.Sprintf("%v:%v", tok.Type, tok.Value))
+		})
+		if fmt.Sprint(got) != c.tokens || fmt.Sprint(errs) != c.errs {
+			t.Errorf("Expected %v %v but got %v %v", c.tokens, c.errs, got, errs)
+			return
+		}
+	}
+}
@@ -0,0 +1,296 @@
+// Package js tokenizes JavaScript sources: the identifiers, the keywords,
+// the numeric, string, template and regular expression literals,
+// the punctuators and the comments. The whitespaces and the line
+// terminators are ignored.
+//
+// A slash starts a regular expression or is a division depending on the
+// token preceding it, the lexer remembers the last significant token it
+// emitted to decide. The template substitutions are lexed as expressions,
+// nested templates and braces included. The escapes of the identifiers
+// are not supported.
+package js
+
+import (
+	"fmt"
+	"io"
+	"unicode"
+
+	lexer "github.com/mh-cbon/state-lexer"
+)
+
+// The token types.
+const (
+	Ident    lexer.TokenType = iota + 1 // name
+	Keyword                             // if, return, typeof...
+	Number                              // 42, 0x2A, 1_000n, .5e3
+	String                              // "text" or 'text'
+	Template                            // `text`, `head${, }middle${ or }tail`
+	RegExp                              // /a+b/g
+	Punct                               // ( ) { } ; => ...
+	Comment                             // // line or /* block */
+)
+
+// Keywords are the reserved words.
+var Keywords = lexer.Keywords{
+	"await": Keyword, "break": Keyword, "case": Keyword, "catch": Keyword,
+	"class": Keyword, "const": Keyword, "continue": Keyword, "debugger": Keyword,
+	"default": Keyword, "delete": Keyword, "do": Keyword, "else": Keyword,
+	"enum": Keyword, "export": Keyword, "extends": Keyword, "false": Keyword,
+	"finally": Keyword, "for": Keyword, "function": Keyword, "if": Keyword,
+	"import": Keyword, "in": Keyword, "instanceof": Keyword, "new": Keyword,
+	"null": Keyword, "return": Keyword, "super": Keyword, "switch": Keyword,
+	"this": Keyword, "throw": Keyword, "true": Keyword, "try": Keyword,
+	"typeof": Keyword, "var": Keyword, "void": Keyword, "while": Keyword,
+	"with": Keyword, "yield": Keyword, "let": Keyword, "static": Keyword,
+}
+
+// operands are the keywords ending an expression, a slash following them
+// is a division.
+var operands = map[string]bool{
+	"this": true, "super": true, "null": true, "true": true, "false": true,
+}
+
+var punctuators = lexer.NewOperatorSet(map[string]lexer.TokenType{
+	"(": Punct, ")": Punct, "[": Punct, "]": Punct, ";": Punct, ",": Punct,
+	"<": Punct, ">": Punct, "<=": Punct, ">=": Punct, "==": Punct, "!=": Punct,
+	"===": Punct, "!==": Punct, "+": Punct, "-": Punct, "*": Punct, "%": Punct,
+	"**": Punct, "++": Punct, "--": Punct, "<<": Punct, ">>": Punct, ">>>": Punct,
+	"&": Punct, "|": Punct, "^": Punct, "!": Punct, "~": Punct, "&&": Punct,
+	"||": Punct, "??": Punct, "?": Punct, "?.": Punct, ":": Punct, "=": Punct,
+	"+=": Punct, "-=": Punct, "*=": Punct, "%=": Punct, "**=": Punct,
+	"<<=": Punct, ">>=": Punct, ">>>=": Punct, "&=": Punct, "|=": Punct,
+	"^=": Punct, "&&=": Punct, "||=": Punct, "??=": Punct, "=>": Punct,
+	".": Punct, "...": Punct, "/": Punct, "/=": Punct,
+})
+
+var (
+	identStart = lexer.NewRuneSet("$_").AddTable(unicode.L).AddTable(unicode.Nl)
+	identPart  = lexer.NewRuneSet("$_\u200c\u200d").AddTable(unicode.L).AddTable(unicode.Nl).
+			AddTable(unicode.Mn).AddTable(unicode.Mc).AddTable(unicode.Nd).AddTable(unicode.Pc)
+	spaces = lexer.NewRuneSet(" \t\v\f\r\n\u00a0\u2028\u2029\ufeff").AddTable(unicode.Zs)
+)
+
+const (
+	templateMode = "template" // a brace closes a template substitution
+	braceMode    = "brace"    // a brace closes a block or an object
+)
+
+var number = lexer.FloatScanner{Sep: '_'}
+
+// New creates a lexer of the script src.
+func New(src io.Reader) *lexer.L {
+	return lexer.New(src, (&script{}).lex)
+}
+
+// NewString creates a lexer of the script src.
+func NewString(src string) *lexer.L {
+	return lexer.NewString(src, (&script{}).lex)
+}
+
+// script holds the last significant token, the lookbehind of the slashes.
+type script struct {
+	prev lexer.Token
+}
+
+// emit emits the current value as a token of type t and remembers it.
+func (s *script) emit(l *lexer.L, t lexer.TokenType) {
+	s.prev = lexer.Token{Type: t, Value: l.Current()}
+	l.Emit(t)
+}
+
+// regexpAllowed reports whether a slash starts a regular expression.
+func (s *script) regexpAllowed() bool {
+	switch s.prev.Type {
+	case Ident, Number, String, RegExp:
+		return false
+	case Keyword:
+		return !operands[s.prev.Value]
+	case Template:
+		v := s.prev.Value
+		return v[len(v)-1] == '{'
+	case Punct:
+		v := s.prev.Value
+		return v != ")" && v != "]" && v != "}" && v != "++" && v != "--"
+	}
+	return true
+}
+
+// lex emits the next token.
+func (s *script) lex(l *lexer.L) lexer.StateFunc {
+	l.TakeSet(spaces)
+	l.Ignore()
+	switch r := l.Peek(); {
+	case r == lexer.EOFRune:
+		if l.Mode() == templateMode {
+			l.Error("unterminated template substitution")
+		}
+		return nil
+	case l.AcceptString("//"):
+		for r := l.Peek(); r != lexer.EOFRune && !isLineTerminator(r); r = l.Peek() {
+			l.Next()
+		}
+		l.Emit(Comment)
+	case l.AcceptString("/*"):
+		for !l.AcceptString("*/") {
+			if l.Next() == lexer.EOFRune {
+				l.Error("unterminated comment")
+				return nil
+			}
+		}
+		l.Emit(Comment)
+	case r == '/' && s.regexpAllowed():
+		return s.lexRegExp
+	case r == '"' || r == '\'':
+		return s.lexString
+	case r == '`':
+		l.Next()
+		return s.lexTemplate
+	case r == '{':
+		l.Next()
+		l.PushMode(braceMode)
+		s.emit(l, Punct)
+	case r == '}':
+		l.Next()
+		if l.Mode() == templateMode {
+			l.PopMode()
+			return s.lexTemplate
+		}
+		l.PopMode()
+		s.emit(l, Punct)
+	case identStart.Contains(r):
+		l.TakeSet(identPart)
+		if t := Keywords[l.Current()]; t != 0 {
+			s.emit(l, t)
+		} else {
+			s.emit(l, Ident)
+		}
+	case r >= '0' && r <= '9' || r == '.' && isDigitNext(l):
+		return s.lexNumber
+	default:
+		t, ok := punctuators.Accept(l)
+		if !ok {
+			l.Error(fmt.Sprintf("unexpected %q", r))
+			return nil
+		}
+		s.emit(l, t)
+	}
+	return s.lex
+}
+
+// lexString emits a quoted string.
+func (s *script) lexString(l *lexer.L) lexer.StateFunc {
+	quote := l.Next()
+	for {
+		switch r := l.Next(); r {
+		case quote:
+			s.emit(l, String)
+			return s.lex
+		case '\\':
+			if l.Next() != lexer.EOFRune {
+				continue
+			}
+			fallthrough
+		case lexer.EOFRune, '\n', '\r':
+			l.Error("unterminated string")
+			return nil
+		}
+	}
+}
+
+// lexTemplate emits the chunk of a template following its opening backtick
+// or the brace closing a substitution.
+func (s *script) lexTemplate(l *lexer.L) lexer.StateFunc {
+	for {
+		switch r := l.Next(); {
+		case r == '`':
+			s.emit(l, Template)
+			return s.lex
+		case r == '$' && l.Peek() == '{':
+			l.Next()
+			l.PushMode(templateMode)
+			s.emit(l, Template)
+			return s.lex
+		case r == '\\':
+			if l.Next() != lexer.EOFRune {
+				continue
+			}
+			fallthrough
+		case r == lexer.EOFRune:
+			l.Error("unterminated template")
+			return nil
+		}
+	}
+}
+
+// lexRegExp emits a regular expression literal and its flags.
+func (s *script) lexRegExp(l *lexer.L) lexer.StateFunc {
+	l.Next()
+	class := false
+	for {
+		switch r := l.Next(); {
+		case r == '/' && !class:
+			l.TakeSet(identPart)
+			s.emit(l, RegExp)
+			return s.lex
+		case r == '[':
+			class = true
+		case r == ']':
+			class = false
+		case r == '\\':
+			if r := l.Next(); r != lexer.EOFRune && r != '\n' && r != '\r' {
+				continue
+			}
+			fallthrough
+		case r == lexer.EOFRune, r == '\n', r == '\r':
+			l.Error("unterminated regular expression")
+			return nil
+		}
+	}
+}
+
+// lexNumber emits a decimal, hexadecimal, octal or binary number,
+// with an optional BigInt suffix.
+func (s *script) lexNumber(l *lexer.L) lexer.StateFunc {
+	radix := 0
+	switch {
+	case l.AcceptStringFold("0x"):
+		radix = 16
+	case l.AcceptStringFold("0o"):
+		radix = 8
+	case l.AcceptStringFold("0b"):
+		radix = 2
+	}
+	if radix > 0 {
+		n, err := l.TakeDigits(radix, '_')
+		if err == nil && n == 0 {
+			err = fmt.Errorf("number %q has no digits", l.Current())
+		}
+		if err != nil {
+			l.Error(err.Error())
+			return nil
+		}
+		l.AcceptString("n")
+	} else if kind, ok := number.Scan(l); !ok {
+		return nil
+	} else if kind == lexer.IntNumber {
+		l.AcceptString("n")
+	}
+	if identStart.Contains(l.Peek()) {
+		l.Error(fmt.Sprintf("identifier directly after number %q", l.Current()))
+		return nil
+	}
+	s.emit(l, Number)
+	return s.lex
+}
+
+// isDigitNext reports whether the rune after the next one is a digit.
+func isDigitNext(l *lexer.L) bool {
+	l.Next()
+	r := l.Peek()
+	l.Rewind()
+	return r >= '0' && r <= '9'
+}
+
+func isLineTerminator(r rune) bool {
+	return r == '\n' || r == '\r' || r == '\u2028' || r == '\u2029'
+}
@@ -0,0 +1,69 @@
+package js
+
+import (
+	"fmt"
+	"testing"
+
+	lexer "github.com/mh-cbon/state-lexer"
+)
+
+func Test_Lex(t *testing.T) {
+	src := "let x = a / b / 2; // half\nif (/[/]+/g.test(s)) return `n=${ {a: 1}.a + `${x}` }!`;\n" +
+		"y = 0x1F + 1_000n + .5e3 /* c */ ?? 'q\\'';\nz = (x) / 2 ++i"
+	l := NewString(src)
+	var got []string
+	err := l.Scan(func(tok lexer.Token) {
+		got = append(got, fmt.Sprintf("%v:%v", tok.Type, tok.Value))
+	})
+	if err != nil {
+		t.Errorf("Expected no error but got %v", err)
+		return
+	}
+	expected := "[2:let 1:x 7:= 1:a 7:/ 1:b 7:/ 3:2 7:; 8:// half 2:if 7:( 6:/[/]+/g 7:. 1:test 7:( 1:s 7:) 7:) " +
+		"2:return 5:`n=${ 7:{ 1:a 7:: 3:1 7:} 7:. 1:a 7:+ 5:`${ 1:x 5:}` 5:}!` 7:; " +
+		"1:y 7:= 3:0x1F 7:+ 3:1_000n 7:+ 3:.5e3 8:/* c */ 7:?? 4:'q\\'' 7:; " +
+		"1:z 7:= 7:( 1:x 7:) 7:/ 3:2 7:++ 1:i]"
+	if fmt.Sprint(got) != expected {
+		t.Errorf("Expected %v but got %v", expected, got)
+		return
+	}
+}
+
+func Test_LexRadixPrefix(t *testing.T) {
+	for i := 0; i < 50; i++ {
+		l := NewString("0x0bf 0x0b1 0B11 0o17n")
+		var got []string
+		err := l.Scan(func(tok lexer.Token) {
+			got = append(got, fmt.Sprintf("%v:%v", tok.Type, tok.Value))
+		})
+		expected := "[3:0x0bf 3:0x0b1 3:0B11 3:0o17n]"
+		if err != nil || fmt.Sprint(got) != expected {
+			t.Errorf("Expected %v but got %v %v", expected, got, err)
+			return
+		}
+	}
+}
+
+func Test_LexErrors(t *testing.T) {
+	for src, expected := range map[string]string{
+		"'a\nb'":    "unterminated string",
+		"`a${b":     "unterminated template substitution",
+		"`a":        "unterminated template",
+		"x = /a\n/": "unterminated regular expression",
+		"/* a":      "unterminated comment",
+		"0x":        `number "0x" has no digits`,
+		"3in":       `identifier directly after number "3"`,
+		"#":         `unexpected '#'`,
+	} {
+		l := NewString(src)
+		var errs []string
+		l.ErrorHandler = func(e string) {
+			errs = append(errs, e)
+		}
+		l.Scan(func(lexer.Token) {})
+		if len(errs) != 1 || errs[0] != expected {
+			t.Errorf("Expected %v but got %v", expected, errs)
+			return
+		}
+	}
+}
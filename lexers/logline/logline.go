@@ -0,0 +1,282 @@
+// Package logline tokenizes log streams, one record per line, in the
+// Common Log Format, the RFC 5424 syslog format or the logfmt format.
+// Each field is emitted as a token of its type, without its quotes or
+// brackets, the escapes of the quoted values are not decoded.
+//
+// A malformed line is reported with Error and skipped up to its newline,
+// the tokens of its valid prefix are kept, the lexing continues with the
+// next line.
+package logline
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	lexer "github.com/mh-cbon/state-lexer"
+)
+
+// The token types.
+const (
+	Host           lexer.TokenType = iota + 1 // client address, or syslog hostname
+	Ident                                     // RFC 1413 identity of the client
+	User                                      // authenticated user
+	Timestamp                                 // 10/Oct/2000:13:55:36 -0700 or 2003-10-11T22:14:15.003Z
+	Request                                   // GET /index.html HTTP/1.0
+	Status                                    // 200
+	Size                                      // 2326
+	Referer                                   // referer of the combined format
+	UserAgent                                 // user agent of the combined format
+	Priority                                  // 34 of <34>
+	Version                                   // 1
+	AppName                                   // su
+	ProcID                                    // 1234
+	MsgID                                     // ID47
+	StructuredData                            // exampleSDID@32473 or - when empty
+	Key                                       // name of a logfmt pair or of a structured data parameter
+	Value                                     // value of a logfmt pair or of a structured data parameter
+	Message                                   // free form message of a syslog record
+	EOL                                       // end of a record
+)
+
+// New creates a lexer of the log src, format is CLF, Syslog or Logfmt.
+// The line endings "\r\n" are read as "\n".
+func New(src io.Reader, format lexer.StateFunc) *lexer.L {
+	l := lexer.New(src, format)
+	l.NormalizeNewlines()
+	return l
+}
+
+// NewString creates a lexer of the log src, see New.
+func NewString(src string, format lexer.StateFunc) *lexer.L {
+	l := lexer.NewString(src, format)
+	l.NormalizeNewlines()
+	return l
+}
+
+// CLF lexes the lines of the Common Log Format, and of the Combined Log
+// Format when the referer and the user agent follow the size:
+//
+//	127.0.0.1 - frank [10/Oct/2000:13:55:36 -0700] "GET / HTTP/1.0" 200 2326
+func CLF(l *lexer.L) lexer.StateFunc {
+	if l.Peek() == lexer.EOFRune {
+		return nil
+	}
+	ok := field(l, Host) && space(l) && field(l, Ident) && space(l) && field(l, User) && space(l) &&
+		enclosed(l, '[', ']', Timestamp) && space(l) && quoted(l, Request) && space(l) &&
+		field(l, Status) && space(l) && field(l, Size)
+	if ok && l.Peek() == ' ' {
+		ok = space(l) && quoted(l, Referer) && space(l) && quoted(l, UserAgent)
+	}
+	return endLine(l, ok, CLF)
+}
+
+// Syslog lexes the lines of the RFC 5424 syslog format:
+//
+//	<34>1 2003-10-11T22:14:15.003Z host su - ID47 [id@1 k="v"] message
+func Syslog(l *lexer.L) lexer.StateFunc {
+	if l.Peek() == lexer.EOFRune {
+		return nil
+	}
+	ok := enclosed(l, '<', '>', Priority) && field(l, Version) && space(l) &&
+		field(l, Timestamp) && space(l) && field(l, Host) && space(l) &&
+		field(l, AppName) && space(l) && field(l, ProcID) && space(l) &&
+		field(l, MsgID) && space(l) && structuredData(l)
+	if ok && l.Peek() == ' ' {
+		l.Next()
+		l.Ignore()
+		takeLine(l)
+		if l.Current() != "" {
+			l.Emit(Message)
+		}
+	}
+	return endLine(l, ok, Syslog)
+}
+
+// Logfmt lexes the key=value pairs of the logfmt format:
+//
+//	level=info msg="request done" took=12ms cached
+//
+// A key without value is emitted alone.
+func Logfmt(l *lexer.L) lexer.StateFunc {
+	l.Take(" \t")
+	l.Ignore()
+	switch r := l.Peek(); r {
+	case lexer.EOFRune:
+		return nil
+	case '\n':
+		return endLine(l, true, Logfmt)
+	case '=', '"':
+		l.Error(fmt.Sprintf("unexpected %q, expected a key", r))
+		return endLine(l, false, Logfmt)
+	}
+	for r := l.Peek(); !isFieldEnd(r) && r != '=' && r != '"'; r = l.Peek() {
+		l.Next()
+	}
+	l.Emit(Key)
+	if l.Peek() != '=' {
+		return Logfmt
+	}
+	l.Next()
+	l.Ignore()
+	if l.Peek() == '"' {
+		if !quoted(l, Value) {
+			return endLine(l, false, Logfmt)
+		}
+		return Logfmt
+	}
+	for !isFieldEnd(l.Peek()) {
+		l.Next()
+	}
+	if l.Current() != "" {
+		l.Emit(Value)
+	}
+	return Logfmt
+}
+
+// structuredData emits the elements of the structured data of a syslog record.
+func structuredData(l *lexer.L) bool {
+	if l.Peek() != '[' {
+		return field(l, StructuredData)
+	}
+	for l.Peek() == '[' {
+		l.Next()
+		l.Ignore()
+		if !name(l, " ]", StructuredData) {
+			return false
+		}
+		for l.Peek() == ' ' {
+			l.Next()
+			l.Ignore()
+			if !name(l, "=", Key) || !expect(l, '=') || !quoted(l, Value) {
+				return false
+			}
+		}
+		if !expect(l, ']') {
+			return false
+		}
+	}
+	return true
+}
+
+// field emits the value up to the next space as a token of type t.
+func field(l *lexer.L, t lexer.TokenType) bool {
+	for !isFieldEnd(l.Peek()) {
+		l.Next()
+	}
+	if l.Current() == "" {
+		l.Error("missing field")
+		return false
+	}
+	l.Emit(t)
+	return true
+}
+
+// name emits the value up to one of stops as a token of type t.
+func name(l *lexer.L, stops string, t lexer.TokenType) bool {
+	for r := l.Peek(); !isFieldEnd(r) && !strings.ContainsRune(stops, r); r = l.Peek() {
+		l.Next()
+	}
+	if l.Current() == "" {
+		l.Error("missing name")
+		return false
+	}
+	l.Emit(t)
+	return true
+}
+
+// enclosed emits the value between open and close as a token of type t.
+func enclosed(l *lexer.L, open, close rune, t lexer.TokenType) bool {
+	if !expect(l, open) {
+		return false
+	}
+	for r := l.Peek(); r != close; r = l.Peek() {
+		if r == '\n' || r == lexer.EOFRune {
+			l.Error(fmt.Sprintf("missing %q", close))
+			return false
+		}
+		l.Next()
+	}
+	l.Emit(t)
+	l.Next()
+	l.Ignore()
+	return true
+}
+
+// quoted emits the value of a quoted string as a token of type t,
+// "-" is accepted as a missing value.
+func quoted(l *lexer.L, t lexer.TokenType) bool {
+	if l.AcceptString("-") {
+		l.Emit(t)
+		return true
+	}
+	if !expect(l, '"') {
+		return false
+	}
+	for {
+		switch l.Peek() {
+		case '"':
+			l.Emit(t)
+			l.Next()
+			l.Ignore()
+			return true
+		case '\\':
+			l.Next()
+			if r := l.Peek(); r == '\n' || r == lexer.EOFRune {
+				break
+			}
+			l.Next()
+			continue
+		case '\n', lexer.EOFRune:
+		default:
+			l.Next()
+			continue
+		}
+		l.Error("unterminated quoted value")
+		return false
+	}
+}
+
+// expect consumes and ignores r.
+func expect(l *lexer.L, r rune) bool {
+	if got := l.Peek(); got != r {
+		l.Error(fmt.Sprintf("unexpected %q, expected %q", got, r))
+		return false
+	}
+	l.Next()
+	l.Ignore()
+	return true
+}
+
+// space consumes and ignores the space separating two fields.
+func space(l *lexer.L) bool {
+	return expect(l, ' ')
+}
+
+// endLine ends the record, the rest of a malformed line is skipped,
+// it returns next to lex the following line.
+func endLine(l *lexer.L, ok bool, next lexer.StateFunc) lexer.StateFunc {
+	if ok && l.Peek() != '\n' && l.Peek() != lexer.EOFRune {
+		l.Error(fmt.Sprintf("unexpected %q at end of record", l.Peek()))
+		ok = false
+	}
+	if !ok {
+		takeLine(l)
+		l.Ignore()
+	}
+	if l.Next() == '\n' {
+		l.Emit(EOL)
+	}
+	return next
+}
+
+// takeLine consumes the input up to the newline.
+func takeLine(l *lexer.L) {
+	for r := l.Peek(); r != '\n' && r != lexer.EOFRune; r = l.Peek() {
+		l.Next()
+	}
+}
+
+func isFieldEnd(r rune) bool {
+	return r == ' ' || r == '\t' || r == '\n' || r == lexer.EOFRune
+}
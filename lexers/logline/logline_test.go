@@ -0,0 +1,65 @@
+package logline
+
+import (
+	"fmt"
+	"testing"
+
+	lexer "github.com/mh-cbon/state-lexer"
+)
+
+func lexAll(src string, format lexer.StateFunc) (tokens, errs []string) {
+	l := NewString(src, format)
+	l.ErrorHandler = func(e string) {
+		errs = append(errs, e)
+	}
+	l.Scan(func(tok lexer.Token) {
+		tokens = append(tokens, fmt.Sprintf("%v:%q", tok.Type, tok.Value))
+	})
+	return tokens, errs
+}
+
+func Test_CLF(t *testing.T) {
+	src := "127.0.0.1 - frank [10/Oct/2000:13:55:36 -0700] \"GET /a.gif HTTP/1.0\" 200 2326\r\n" +
+		"bad line\n" +
+		"::1 - - [10/Oct/2000:13:55:37 -0700] \"GET / HTTP/1.1\" 304 - \"-\" \"curl/7.1 \\\"x\\\"\""
+	tokens, errs := lexAll(src, CLF)
+	expected := `[1:"127.0.0.1" 2:"-" 3:"frank" 4:"10/Oct/2000:13:55:36 -0700" 5:"GET /a.gif HTTP/1.0" 6:"200" 7:"2326" 19:"\n" ` +
+		`1:"bad" 2:"line" 19:"\n" ` +
+		`1:"::1" 2:"-" 3:"-" 4:"10/Oct/2000:13:55:37 -0700" 5:"GET / HTTP/1.1" 6:"304" 7:"-" 8:"-" 9:"curl/7.1 \\\"x\\\""]`
+	if fmt.Sprint(tokens) != expected {
+		t.Errorf("Expected %v but got %v", expected, tokens)
+		return
+	}
+	if fmt.Sprint(errs) != `[unexpected '\n', expected ' ']` {
+		t.Errorf("Expected %v but got %v", `[unexpected '\n', expected ' ']`, errs)
+		return
+	}
+}
+
+func Test_Syslog(t *testing.T) {
+	src := "<165>1 2003-10-11T22:14:15.003Z mymachine evntslog - ID47 [exampleSDID@32473 iut=\"3\" eventSource=\"App\\]\"][x@1] An event\n" +
+		"<34>1 2003-10-11T22:14:15.003Z host su - - -"
+	tokens, errs := lexAll(src, Syslog)
+	expected := `[10:"165" 11:"1" 4:"2003-10-11T22:14:15.003Z" 1:"mymachine" 12:"evntslog" 13:"-" 14:"ID47" ` +
+		`15:"exampleSDID@32473" 16:"iut" 17:"3" 16:"eventSource" 17:"App\\]" 15:"x@1" 18:"An event" 19:"\n" ` +
+		`10:"34" 11:"1" 4:"2003-10-11T22:14:15.003Z" 1:"host" 12:"su" 13:"-" 14:"-" 15:"-"]`
+	if fmt.Sprint(tokens) != expected || len(errs) > 0 {
+		t.Errorf("Expected %v but got %v %v", expected, tokens, errs)
+		return
+	}
+}
+
+func Test_Logfmt(t *testing.T) {
+	src := "level=info msg=\"request \\\"done\\\"\" took=12ms cached empty=\n=x a=\"b\nc=d"
+	tokens, errs := lexAll(src, Logfmt)
+	expected := `[16:"level" 17:"info" 16:"msg" 17:"request \\\"done\\\"" 16:"took" 17:"12ms" 16:"cached" 16:"empty" 19:"\n" ` +
+		`19:"\n" 16:"c" 17:"d"]`
+	if fmt.Sprint(tokens) != expected {
+		t.Errorf("Expected %v but got %v", expected, tokens)
+		return
+	}
+	if fmt.Sprint(errs) != `[unexpected '=', expected a key]` {
+		t.Errorf("Expected %v but got %v", `[unexpected '=', expected a key]`, errs)
+		return
+	}
+}
@@ -0,0 +1,191 @@
+// Package mailheader tokenizes the header sections of RFC 5322 messages
+// and of their MIME parts: the field names, the unstructured values, and
+// the atoms, quoted strings, comments and specials of the structured values.
+// The folded lines are emitted as Fold tokens, the comments and quoted
+// strings may span them.
+//
+// The lexing stops at the blank line ending the header section, the body
+// is not read. The line endings "\r\n" are read as "\n", the offsets of
+// the tokens still refer to the original bytes.
+package mailheader
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	lexer "github.com/mh-cbon/state-lexer"
+)
+
+// The token types.
+const (
+	FieldName     lexer.TokenType = iota + 1 // Subject
+	Colon                                    // : following the field name
+	Text                                     // unstructured value
+	Atom                                     // john.doe, or the words of a phrase
+	QuotedString                             // "John Doe"
+	Comment                                  // (nested (comments))
+	DomainLiteral                            // [192.0.2.1]
+	Special                                  // < > @ , ; : \
+	Fold                                     // line break followed by whitespace
+	EndOfField                               // line break ending a field
+	EndOfHeader                              // blank line ending the header section
+)
+
+// Unstructured are the lower cased names of the fields whose values are
+// emitted as Text, the values of the other fields are structured.
+var Unstructured = map[string]bool{
+	"subject":             true,
+	"comments":            true,
+	"content-description": true,
+}
+
+const (
+	wsp      = " \t"
+	specials = "()<>[]:;@\\,\""
+)
+
+// New creates a lexer of the header section src.
+func New(src io.Reader) *lexer.L {
+	l := lexer.New(src, (&header{}).fieldStart)
+	l.NormalizeNewlines()
+	return l
+}
+
+// NewString creates a lexer of the header section src.
+func NewString(src string) *lexer.L {
+	l := lexer.NewString(src, (&header{}).fieldStart)
+	l.NormalizeNewlines()
+	return l
+}
+
+// header holds the kind of the value of the current field.
+type header struct {
+	structured bool
+}
+
+// fieldStart lexes a field name and its colon, at the start of a line.
+func (h *header) fieldStart(l *lexer.L) lexer.StateFunc {
+	switch l.Peek() {
+	case lexer.EOFRune:
+		return nil
+	case '\n':
+		l.Next()
+		l.Emit(EndOfHeader)
+		return nil
+	case ' ', '\t':
+		l.Error("continuation line without field")
+		return skipLine(l, h.fieldStart)
+	}
+	for r := l.Peek(); r > ' ' && r < 0x7f && r != ':'; r = l.Peek() {
+		l.Next()
+	}
+	name := l.Current()
+	if name == "" {
+		l.Error(fmt.Sprintf("unexpected %q in field name", l.Peek()))
+		return skipLine(l, h.fieldStart)
+	}
+	l.Emit(FieldName)
+	l.Take(wsp)
+	l.Ignore()
+	if l.Peek() != ':' {
+		l.Error(fmt.Sprintf("missing colon after field name %q", name))
+		return skipLine(l, h.fieldStart)
+	}
+	l.Next()
+	l.Emit(Colon)
+	h.structured = !Unstructured[strings.ToLower(name)]
+	return h.value
+}
+
+// value lexes the next token of a field value.
+func (h *header) value(l *lexer.L) lexer.StateFunc {
+	l.Take(wsp)
+	l.Ignore()
+	switch r := l.Peek(); {
+	case r == lexer.EOFRune:
+		return nil
+	case r == '\n':
+		l.Next()
+		if !folded(l) {
+			l.Emit(EndOfField)
+			return h.fieldStart
+		}
+		l.Take(wsp)
+		l.Emit(Fold)
+	case !h.structured:
+		for r := l.Peek(); r != '\n' && r != lexer.EOFRune; r = l.Peek() {
+			l.Next()
+		}
+		l.Emit(Text)
+	case r == '(':
+		enclosed(l, '(', ')', Comment)
+	case r == '"':
+		enclosed(l, '"', '"', QuotedString)
+	case r == '[':
+		enclosed(l, '[', ']', DomainLiteral)
+	case strings.ContainsRune(specials, r):
+		l.Next()
+		l.Emit(Special)
+	default:
+		for r := l.Peek(); isAtext(r); r = l.Peek() {
+			l.Next()
+		}
+		if l.Current() == "" {
+			l.Error(fmt.Sprintf("unexpected %q in field value", r))
+			l.Next()
+			l.Ignore()
+		} else {
+			l.Emit(Atom)
+		}
+	}
+	return h.value
+}
+
+// enclosed emits the value from open to close as a token of type t,
+// with its quoted pairs and folds. The comments nest. An unterminated
+// value is reported and emitted up to the end of the field.
+func enclosed(l *lexer.L, open, close rune, t lexer.TokenType) {
+	l.Next()
+	depth := 1
+	for {
+		switch r := l.Next(); {
+		case r == close:
+			if depth--; depth == 0 {
+				l.Emit(t)
+				return
+			}
+		case r == open && t == Comment:
+			depth++
+		case r == '\\':
+			if r := l.Peek(); r != '\n' && r != lexer.EOFRune {
+				l.Next()
+			}
+		case r == '\n' && folded(l):
+		case r == '\n', r == lexer.EOFRune:
+			l.Rewind()
+			l.Error(fmt.Sprintf("missing %q", close))
+			l.Emit(t)
+			return
+		}
+	}
+}
+
+// folded reports whether the line following a line break continues the field.
+func folded(l *lexer.L) bool {
+	r := l.Peek()
+	return r == ' ' || r == '\t'
+}
+
+// skipLine ignores the rest of the line, with its line break.
+func skipLine(l *lexer.L, next lexer.StateFunc) lexer.StateFunc {
+	for r := l.Next(); r != '\n' && r != lexer.EOFRune; r = l.Next() {
+	}
+	l.Ignore()
+	return next
+}
+
+// isAtext reports whether r is part of an atom, or of a dot-atom.
+func isAtext(r rune) bool {
+	return r > ' ' && r != 0x7f && !strings.ContainsRune(specials, r)
+}
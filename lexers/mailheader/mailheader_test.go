@@ -0,0 +1,68 @@
+package mailheader
+
+import (
+	"fmt"
+	"testing"
+
+	lexer "github.com/mh-cbon/state-lexer"
+)
+
+func Test_Lex(t *testing.T) {
+	src := "From: \"Doe, John\" <john.doe@example.com> (home\r\n (nested))\r\n" +
+		"Subject: Re: (not a comment)\r\n \"folded\"\r\n" +
+		"Received: from [192.0.2.1]; Tue, 1 Jul 2003\r\n" +
+		"\r\n" +
+		"body: ignored\r\n"
+	l := NewString(src)
+	var got []string
+	err := l.Scan(func(tok lexer.Token) {
+		got = append(got, fmt.Sprintf("%v:%q", tok.Type, tok.Value))
+	})
+	if err != nil {
+		t.Errorf("Expected no error but got %v", err)
+		return
+	}
+	expected := `[1:"From" 2:":" 5:"\"Doe, John\"" 8:"<" 4:"john.doe" 8:"@" 4:"example.com" 8:">" 6:"(home\n (nested))" 10:"\n" ` +
+		`1:"Subject" 2:":" 3:"Re: (not a comment)" 9:"\n " 3:"\"folded\"" 10:"\n" ` +
+		`1:"Received" 2:":" 4:"from" 7:"[192.0.2.1]" 8:";" 4:"Tue" 8:"," 4:"1" 4:"Jul" 4:"2003" 10:"\n" 11:"\n"]`
+	if fmt.Sprint(got) != expected {
+		t.Errorf("Expected %v but got %v", expected, got)
+		return
+	}
+}
+
+func Test_Positions(t *testing.T) {
+	l := NewString("A: b\r\nTo: (x\r\nC: d")
+	l.ErrorHandler = func(string) {}
+	var errs []*lexer.LexError
+	l.OnError(func(e *lexer.LexError) {
+		errs = append(errs, e)
+	})
+	l.Scan(func(lexer.Token) {})
+	if len(errs) != 1 || errs[0].Msg != `missing ')'` || errs[0].Line != 2 || errs[0].Col != 5 {
+		t.Errorf("Expected %v but got %v", `missing ')' at 2:5`, errs)
+		return
+	}
+}
+
+func Test_LexErrors(t *testing.T) {
+	for src, expected := range map[string]string{
+		" a: b\n":       "continuation line without field",
+		"A b\n":         `missing colon after field name "A"`,
+		":b\n":          `unexpected ':' in field name`,
+		"To: \"a\n":     `missing '"'`,
+		"To: a\x01b\n":  `unexpected '\x01' in field value`,
+		"X-Y: [1.2\nZ:": `missing ']'`,
+	} {
+		l := NewString(src)
+		var errs []string
+		l.ErrorHandler = func(e string) {
+			errs = append(errs, e)
+		}
+		l.Scan(func(lexer.Token) {})
+		if len(errs) != 1 || errs[0] != expected {
+			t.Errorf("Expected %v but got %v", expected, errs)
+			return
+		}
+	}
+}
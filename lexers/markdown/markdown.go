@@ -0,0 +1,225 @@
+// Package markdown tokenizes the blocks of CommonMark documents:
+// the headings, the code fences, the list items, the block quotes
+// and the paragraph lines. The inline content is emitted as Text.
+//
+// The container blocks are recognized at the start of each line,
+// the fences are not tracked through them.
+package markdown
+
+import (
+	"io"
+	"strings"
+
+	lexer "github.com/mh-cbon/state-lexer"
+)
+
+// The token types.
+const (
+	Heading          lexer.TokenType = iota + 1 // # to ######
+	FenceOpen                                   // ``` or ~~~
+	Info                                        // info string of a fence, such as go
+	Code                                        // line of a fenced code block, with its newline
+	FenceClose                                  // ``` or ~~~
+	ListMarker                                  // -, *, + or 1. and 1)
+	BlockquoteMarker                            // >
+	ThematicBreak                               // ---, *** or ___
+	Text                                        // content of a line
+	BlankLine                                   // \n
+)
+
+// New creates a lexer of the markdown document src.
+func New(src io.Reader) *lexer.L {
+	return lexer.New(src, (&blocks{}).lineStart)
+}
+
+// NewString creates a lexer of the markdown document src.
+func NewString(src string) *lexer.L {
+	return lexer.NewString(src, (&blocks{}).lineStart)
+}
+
+// blocks holds the open code fence.
+type blocks struct {
+	fence string
+}
+
+// lineStart recognizes the blocks starting the line.
+func (b *blocks) lineStart(l *lexer.L) lexer.StateFunc {
+	if b.fence != "" {
+		return b.fenceLine
+	}
+	return b.blockStart
+}
+
+// blockStart recognizes a block, at the start of a line or after a container marker.
+func (b *blocks) blockStart(l *lexer.L) lexer.StateFunc {
+	indent(l)
+	switch r := l.Peek(); {
+	case r == lexer.EOFRune:
+		return nil
+	case r == '\n':
+		l.Next()
+		l.Emit(BlankLine)
+		return b.lineStart
+	case r == '>':
+		l.Next()
+		l.Emit(BlockquoteMarker)
+		if l.Peek() == ' ' {
+			l.Next()
+			l.Ignore()
+		}
+		return b.blockStart
+	case r == '#':
+		return b.heading
+	case r == '`' || r == '~':
+		return b.fenceOpen
+	case thematicBreak(l):
+		l.Emit(ThematicBreak)
+		return b.endLine
+	case listMarker(l):
+		l.Emit(ListMarker)
+		l.Next()
+		l.Ignore()
+		return b.blockStart
+	}
+	return b.text
+}
+
+// heading emits an ATX heading marker, or falls back to text.
+func (b *blocks) heading(l *lexer.L) lexer.StateFunc {
+	l.Take("#")
+	if n := len(l.Current()); n > 6 || !endsMarker(l.Peek()) {
+		l.AbortToken()
+		return b.text
+	}
+	l.Emit(Heading)
+	l.Take(" \t")
+	l.Ignore()
+	return b.text
+}
+
+// fenceOpen emits an opening code fence and its info string, or falls back to text.
+func (b *blocks) fenceOpen(l *lexer.L) lexer.StateFunc {
+	c := l.Peek()
+	l.Take(string(c))
+	fence := l.Current()
+	if len(fence) < 3 {
+		l.AbortToken()
+		return b.text
+	}
+	l.TakeUntilByte('\n')
+	info := strings.TrimSpace(l.Current()[len(fence):])
+	if c == '`' && strings.ContainsRune(info, '`') {
+		l.AbortToken()
+		return b.text
+	}
+	l.AbortToken()
+	l.Take(string(c))
+	l.Emit(FenceOpen)
+	l.Take(" \t")
+	l.Ignore()
+	if info != "" {
+		l.AcceptString(info)
+		l.Emit(Info)
+	}
+	b.fence = fence
+	return b.endLine
+}
+
+// fenceLine emits a line of a fenced code block, or its closing fence.
+func (b *blocks) fenceLine(l *lexer.L) lexer.StateFunc {
+	if l.Peek() == lexer.EOFRune {
+		return nil
+	}
+	for i := 0; i < 3 && l.Peek() == ' '; i++ {
+		l.Next()
+	}
+	l.Take(b.fence[:1])
+	closing := strings.TrimLeft(l.Current(), " ")
+	if len(closing) >= len(b.fence) {
+		l.Take(" \t")
+		if r := l.Peek(); r == '\n' || r == lexer.EOFRune {
+			l.AbortToken()
+			indent(l)
+			l.Take(b.fence[:1])
+			l.Emit(FenceClose)
+			b.fence = ""
+			return b.endLine
+		}
+	}
+	l.AbortToken()
+	l.TakeUntilByte('\n')
+	l.Next()
+	l.Emit(Code)
+	return b.lineStart
+}
+
+// text emits the rest of the line.
+func (b *blocks) text(l *lexer.L) lexer.StateFunc {
+	l.TakeUntilByte('\n')
+	if l.Current() != "" {
+		l.Emit(Text)
+	}
+	return b.endLine
+}
+
+// endLine ignores the end of the line.
+func (b *blocks) endLine(l *lexer.L) lexer.StateFunc {
+	l.Take(" \t")
+	l.Next()
+	l.Ignore()
+	return b.lineStart
+}
+
+// indent ignores up to 3 spaces of indentation.
+func indent(l *lexer.L) {
+	for i := 0; i < 3 && l.Peek() == ' '; i++ {
+		l.Next()
+	}
+	l.Ignore()
+}
+
+// endsMarker reports whether r can follow a block marker.
+func endsMarker(r rune) bool {
+	return r == ' ' || r == '\t' || r == '\n' || r == lexer.EOFRune
+}
+
+// thematicBreak consumes a line of 3 or more -, * or _, with spaces,
+// otherwise it consumes nothing.
+func thematicBreak(l *lexer.L) bool {
+	c := l.Peek()
+	if c != '-' && c != '*' && c != '_' {
+		return false
+	}
+	l.TakeUntilByte('\n')
+	line := strings.TrimRight(l.Current(), " \t")
+	if strings.Count(line, string(c)) >= 3 && strings.Trim(line, string(c)+" \t") == "" {
+		l.AbortToken()
+		l.AcceptString(line)
+		return true
+	}
+	l.AbortToken()
+	return false
+}
+
+// listMarker consumes a bullet or an ordered list marker followed by a space,
+// otherwise it consumes nothing.
+func listMarker(l *lexer.L) bool {
+	if !l.AcceptSet(bullets) {
+		for i := 0; i < 9 && l.AcceptSet(lexer.Digits); i++ {
+		}
+		if l.Current() == "" || !l.AcceptSet(orderedEnds) {
+			l.AbortToken()
+			return false
+		}
+	}
+	if r := l.Peek(); r != ' ' && r != '\t' {
+		l.AbortToken()
+		return false
+	}
+	return true
+}
+
+var (
+	bullets     = lexer.NewRuneSet("-*+")
+	orderedEnds = lexer.NewRuneSet(".)")
+)
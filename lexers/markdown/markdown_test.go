@@ -0,0 +1,25 @@
+package markdown
+
+import (
+	"fmt"
+	"testing"
+
+	lexer "github.com/mh-cbon/state-lexer"
+)
+
+func Test_Lex(t *testing.T) {
+	src := "# Title #\n\nSome *text*\n> - item\n  10. ten\n---\n```go  \nfunc()\n  x\n ```\n####### no\n~~~\n```\n"
+	l := NewString(src)
+	var got []string
+	l.Scan(func(tok lexer.Token) {
+		got = append(got, fmt.Sprintf("%v:%q@%v:%v", tok.Type, tok.Value, tok.Line, tok.Col))
+	})
+	expected := `[1:"#"@1:1 9:"Title #"@1:3 10:"\n"@2:1 9:"Some *text*"@3:1 ` +
+		`7:">"@4:1 6:"-"@4:3 9:"item"@4:5 6:"10."@5:3 9:"ten"@5:7 8:"---"@6:1 ` +
+		"2:\"```\"@7:1 3:\"go\"@7:4 4:\"func()\\n\"@8:1 4:\"  x\\n\"@9:1 5:\"```\"@10:2 " +
+		"9:\"####### no\"@11:1 2:\"~~~\"@12:1 4:\"```\\n\"@13:1]"
+	if fmt.Sprint(got) != expected {
+		t.Errorf("Expected %v but got %v", expected, got)
+		return
+	}
+}
@@ -0,0 +1,229 @@
+// Package template tokenizes the {{ ... }} templates of text/template.
+//
+// The lexer has two modes: the literal text, and the actions between the
+// delimiters. Its states dispatch on the mode of the lexer rather than
+// chaining each other: the mode alone tells how to continue.
+package template
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"unicode"
+
+	lexer "github.com/mh-cbon/state-lexer"
+)
+
+// The token types.
+const (
+	Text       lexer.TokenType = iota + 1 // literal text
+	LeftDelim                             // {{ or {{- trimming the preceding spaces
+	RightDelim                            // }} or -}} trimming the following spaces
+	Keyword                               // if, range, end...
+	Ident                                 // function name
+	Field                                 // .Name, or . alone
+	Variable                              // $name, or $ alone
+	String                                // "quoted" or `raw` string
+	Char                                  // 'c'
+	Number                                // 42, 0x2A, 1e3
+	Op                                    // ( ) | , = :=
+	Comment                               // /* comment */
+)
+
+// Keywords are the keywords of the actions.
+var Keywords = lexer.Keywords{
+	"block": Keyword, "break": Keyword, "continue": Keyword, "define": Keyword,
+	"else": Keyword, "end": Keyword, "if": Keyword, "nil": Keyword,
+	"range": Keyword, "template": Keyword, "with": Keyword,
+	"true": Keyword, "false": Keyword,
+}
+
+const (
+	leftDelim   = "{{"
+	rightDelim  = "}}"
+	trimMarker  = '-'
+	actionMode  = "action"
+	spaceChars  = " \t\r\n"
+	numberStart = "0123456789+-"
+)
+
+var number = lexer.FloatScanner{Sign: true, Sep: '_', Hex: true}
+
+// New creates a lexer of the template src.
+func New(src io.Reader) *lexer.L {
+	return lexer.New(src, Lex)
+}
+
+// NewString creates a lexer of the template src.
+func NewString(src string) *lexer.L {
+	return lexer.NewString(src, Lex)
+}
+
+// Lex is the initial state, it lexes the text or the action, by mode.
+func Lex(l *lexer.L) lexer.StateFunc {
+	if l.Mode() == actionMode {
+		return lexAction(l)
+	}
+	return lexText(l)
+}
+
+// lexText emits the text until the next action.
+func lexText(l *lexer.L) lexer.StateFunc {
+	for !lookingAt(l, leftDelim) {
+		if l.Next() == lexer.EOFRune {
+			if l.Current() != "" {
+				l.Emit(Text)
+			}
+			return nil
+		}
+	}
+	trim := leftTrim(l)
+	if trim {
+		text := l.Current()
+		for i := len(text) - len(strings.TrimRight(text, spaceChars)); i > 0; i-- {
+			l.Rewind()
+		}
+	}
+	if l.Current() != "" {
+		l.Emit(Text)
+	}
+	if trim {
+		l.Take(spaceChars)
+		l.Ignore()
+		l.AcceptString(leftDelim + string(trimMarker))
+	} else {
+		l.AcceptString(leftDelim)
+	}
+	l.Emit(LeftDelim)
+	l.PushMode(actionMode)
+	return Lex
+}
+
+// lexAction emits the next token of an action.
+func lexAction(l *lexer.L) lexer.StateFunc {
+	spaced := strings.ContainsRune(spaceChars, l.Peek())
+	l.Take(spaceChars)
+	l.Ignore()
+	switch r := l.Peek(); {
+	case spaced && lookingAt(l, string(trimMarker)+rightDelim):
+		l.AcceptString(string(trimMarker) + rightDelim)
+		l.Emit(RightDelim)
+		l.PopMode()
+		l.Take(spaceChars)
+		l.Ignore()
+	case lookingAt(l, rightDelim):
+		l.AcceptString(rightDelim)
+		l.Emit(RightDelim)
+		l.PopMode()
+	case r == lexer.EOFRune:
+		l.Error("unclosed action")
+		return nil
+	case lookingAt(l, "/*"):
+		return lexComment(l)
+	case r == '"' || r == '`' || r == '\'':
+		return lexQuote(l)
+	case r == '.' || r == '$':
+		l.Next()
+		if r == '.' && strings.ContainsRune("0123456789", l.Peek()) {
+			l.Rewind()
+			return lexNumber(l)
+		}
+		takeIdent(l)
+		if r == '.' {
+			l.Emit(Field)
+		} else {
+			l.Emit(Variable)
+		}
+	case strings.ContainsRune(numberStart, r):
+		return lexNumber(l)
+	case r == '_' || unicode.IsLetter(r):
+		takeIdent(l)
+		l.EmitKeywordOr(Keywords, Ident)
+	case l.AcceptString(":="):
+		l.Emit(Op)
+	case strings.ContainsRune("()|,=", r):
+		l.Next()
+		l.Emit(Op)
+	default:
+		l.Error(fmt.Sprintf("unexpected %q in action", r))
+		return nil
+	}
+	return Lex
+}
+
+func lexComment(l *lexer.L) lexer.StateFunc {
+	for !l.AcceptString("*/") {
+		if l.Next() == lexer.EOFRune {
+			l.Error("unclosed comment")
+			return nil
+		}
+	}
+	l.Emit(Comment)
+	return Lex
+}
+
+// lexQuote emits a quoted string, a raw string or a char, the delimiters
+// of the action are part of it.
+func lexQuote(l *lexer.L) lexer.StateFunc {
+	quote := l.Next()
+	for {
+		switch r := l.Next(); {
+		case r == quote:
+			if quote == '\'' {
+				l.Emit(Char)
+			} else {
+				l.Emit(String)
+			}
+			return Lex
+		case r == '\\' && quote != '`':
+			if l.Next() != lexer.EOFRune {
+				continue
+			}
+			fallthrough
+		case r == lexer.EOFRune, r == '\n' && quote != '`':
+			l.Error("unterminated quoted string")
+			return nil
+		}
+	}
+}
+
+func lexNumber(l *lexer.L) lexer.StateFunc {
+	if _, ok := number.Scan(l); !ok {
+		if l.Current() == "" {
+			l.Error(fmt.Sprintf("unexpected %q in action", l.Peek()))
+		}
+		return nil
+	}
+	l.Emit(Number)
+	return Lex
+}
+
+func takeIdent(l *lexer.L) {
+	for r := l.Peek(); r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r); r = l.Peek() {
+		l.Next()
+	}
+}
+
+// leftTrim reports whether the input continues with a left delimiter,
+// a trim marker and a space, without consuming it.
+func leftTrim(l *lexer.L) bool {
+	if !l.AcceptString(leftDelim + string(trimMarker)) {
+		return false
+	}
+	r := l.Next()
+	for i := 0; i < len(leftDelim)+2; i++ {
+		l.Rewind()
+	}
+	return r != lexer.EOFRune && strings.ContainsRune(spaceChars, r)
+}
+
+// lookingAt reports whether the input continues with s, without consuming it.
+func lookingAt(l *lexer.L, s string) bool {
+	if !l.AcceptString(s) {
+		return false
+	}
+	for range s {
+		l.Rewind()
+	}
+	return true
+}
@@ -0,0 +1,49 @@
+package template
+
+import (
+	"fmt"
+	"testing"
+
+	lexer "github.com/mh-cbon/state-lexer"
+)
+
+func Test_Lex(t *testing.T) {
+	src := "Hi {{.Name}}!\n  {{- range $i, $v := .Items -}}\n  [{{ $v | printf \"%q}}\" 'x' 1.5e3 }}]{{/* c */}}{{end}}"
+	l := NewString(src)
+	var got []string
+	err := l.Scan(func(tok lexer.Token) {
+		got = append(got, fmt.Sprintf("%v:%q", tok.Type, tok.Value))
+	})
+	if err != nil {
+		t.Errorf("Expected no error but got %v", err)
+		return
+	}
+	expected := `[1:"Hi " 2:"{{" 6:".Name" 3:"}}" 1:"!" 2:"{{-" 4:"range" 7:"$i" 11:"," 7:"$v" 11:":=" 6:".Items" 3:"-}}" ` +
+		`1:"[" 2:"{{" 7:"$v" 11:"|" 5:"printf" 8:"\"%q}}\"" 9:"'x'" 10:"1.5e3" 3:"}}" 1:"]" 2:"{{" 12:"/* c */" 3:"}}" ` +
+		`2:"{{" 4:"end" 3:"}}"]`
+	if fmt.Sprint(got) != expected {
+		t.Errorf("Expected %v but got %v", expected, got)
+		return
+	}
+}
+
+func Test_LexErrors(t *testing.T) {
+	for src, expected := range map[string]string{
+		"a {{ .X":    "unclosed action",
+		"{{ \"a }}":  "unterminated quoted string",
+		"{{ /* a }}": "unclosed comment",
+		"{{ # }}":    `unexpected '#' in action`,
+		"{{ 1e+ }}":  `exponent of "1e+" has no digits`,
+	} {
+		l := NewString(src)
+		var errs []string
+		l.ErrorHandler = func(e string) {
+			errs = append(errs, e)
+		}
+		l.All()
+		if fmt.Sprint(errs) != "["+expected+"]" {
+			t.Errorf("Expected %v but got %v for %q", expected, errs, src)
+			return
+		}
+	}
+}
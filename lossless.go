@@ -0,0 +1,62 @@
+package lexer
+
+import "fmt"
+
+// Lossless makes every rune of the input represented in the token stream:
+// the ignored values are emitted as tokens of type skipped rather than dropped,
+// and so is the input left once the lexing ends, after an error for example.
+// Concatenating the token values then reproduces the input, unless the
+// states build values with AppendRune or insert tokens, see VerifyRoundTrip.
+func (l *L) Lossless(skipped TokenType) {
+	l.lossless, l.skipped = true, skipped
+}
+
+// skipRest emits the input left as a skipped token.
+func (l *L) skipRest() {
+	for l.Next() != EOFRune {
+	}
+	l.position = len(l.buf) // past MaxBuffer, the buffered input only
+	if l.position > l.start {
+		l.Emit(l.skipped)
+	}
+}
+
+// RoundTripError locates the first input range not reproduced by the tokens,
+// see VerifyRoundTrip.
+type RoundTripError struct {
+	Start, End int // byte offsets of the range in the input
+	Msg        string
+}
+
+func (e *RoundTripError) Error() string {
+	return fmt.Sprintf("bytes %d-%d: %s", e.Start, e.End, e.Msg)
+}
+
+// VerifyRoundTrip checks that the values of tokens reproduce src, as in the
+// lossless mode, using their offsets. It returns a *RoundTripError for the
+// first byte range which is not covered, covered twice or which differs.
+// src must be valid UTF-8.
+func VerifyRoundTrip(src []byte, tokens []Token) error {
+	pos := 0
+	for _, t := range tokens {
+		end := t.Offset + len(t.Value)
+		switch {
+		case t.Offset > pos:
+			return &RoundTripError{Start: pos, End: t.Offset, Msg: "not covered by any token"}
+		case t.Offset < pos:
+			return &RoundTripError{Start: t.Offset, End: pos,
+				Msg: fmt.Sprintf("covered again by the token %d", t.Index)}
+		case end > len(src) || string(src[pos:end]) != t.Value:
+			if end > len(src) {
+				end = len(src)
+			}
+			return &RoundTripError{Start: pos, End: end,
+				Msg: fmt.Sprintf("differs from the token %d %q", t.Index, t.Value)}
+		}
+		pos = end
+	}
+	if pos < len(src) {
+		return &RoundTripError{Start: pos, End: len(src), Msg: "not covered by any token"}
+	}
+	return nil
+}
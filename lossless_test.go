@@ -0,0 +1,64 @@
+package lexer
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func Test_Lossless(t *testing.T) {
+	const SkippedToken TokenType = 10
+	src := "12.ab \n 3.c,d e"
+	l := New(bytes.NewBufferString(src), numberState)
+	l.ErrorHandler = func(string) {}
+	l.Lossless(SkippedToken)
+	tokens, err := l.All()
+	if err == nil {
+		t.Error("Expected an error, but none found.")
+		return
+	}
+	var out strings.Builder
+	for _, tok := range tokens {
+		out.WriteString(tok.Value)
+	}
+	if out.String() != src {
+		t.Errorf("Expected %q but got %q", src, out.String())
+		return
+	}
+	if last := tokens[len(tokens)-1]; last.Type != SkippedToken || last.Value != ",d e" {
+		t.Errorf("Unexpected last token %v", last)
+		return
+	}
+}
+
+func Test_VerifyRoundTrip(t *testing.T) {
+	src := []byte("12.ab 3")
+	tokens, _ := New(bytes.NewReader(src), numberState).All()
+	err := VerifyRoundTrip(src, tokens)
+	if e, ok := err.(*RoundTripError); !ok || e.Start != 5 || e.End != 6 {
+		t.Errorf("Expected an error on bytes 5-6 but got %v", err)
+		return
+	}
+
+	l := New(bytes.NewReader(src), numberState)
+	l.Lossless(10)
+	tokens, _ = l.All()
+	if err := VerifyRoundTrip(src, tokens); err != nil {
+		t.Errorf("Unexpected error %v", err)
+		return
+	}
+
+	tokens[1].Value = "!"
+	if err := VerifyRoundTrip(src, tokens); err == nil || err.Error() != `bytes 2-3: differs from the token 1 "!"` {
+		t.Errorf("Unexpected error %v", err)
+		return
+	}
+	if err := VerifyRoundTrip(src, append(tokens[:1], tokens...)); err == nil || err.Error() != "bytes 0-2: covered again by the token 0" {
+		t.Errorf("Unexpected error %v", err)
+		return
+	}
+	if err := VerifyRoundTrip(append(src, '!'), tokens[:0]); err == nil || err.Error() != "bytes 0-8: not covered by any token" {
+		t.Errorf("Unexpected error %v", err)
+		return
+	}
+}
@@ -0,0 +1,114 @@
+package lexer
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Matcher consumes a part of the input and returns true when it matches,
+// otherwise it rewinds what it consumed and returns false.
+//
+//	hex := lexer.Seq(lexer.Lit("0x"), lexer.Rep1(lexer.MatchHexDigit))
+//	state := lexer.Match(hex, HexToken, nextState)
+type Matcher func(l *L) bool
+
+var (
+	// MatchDigit matches a decimal digit, see Digits.
+	MatchDigit = InSet(Digits)
+	// MatchHexDigit matches an hexadecimal digit, see HexDigits.
+	MatchHexDigit = InSet(HexDigits)
+)
+
+// Lit matches s.
+func Lit(s string) Matcher {
+	return func(l *L) bool {
+		return l.AcceptString(s)
+	}
+}
+
+// Set matches one of chars.
+func Set(chars string) Matcher {
+	return func(l *L) bool {
+		r := l.Next()
+		if r == EOFRune || !strings.ContainsRune(chars, r) {
+			l.Rewind()
+			return false
+		}
+		return true
+	}
+}
+
+// InSet matches one rune of s.
+func InSet(s *RuneSet) Matcher {
+	return func(l *L) bool {
+		return l.AcceptSet(s)
+	}
+}
+
+// Seq matches all ms in order.
+func Seq(ms ...Matcher) Matcher {
+	return func(l *L) bool {
+		mark := l.position
+		for _, m := range ms {
+			if !m(l) {
+				l.rewindTo(mark)
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// Alt matches the first matching of ms.
+func Alt(ms ...Matcher) Matcher {
+	return func(l *L) bool {
+		for _, m := range ms {
+			if m(l) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// Opt matches m, or nothing.
+func Opt(m Matcher) Matcher {
+	return func(l *L) bool {
+		m(l)
+		return true
+	}
+}
+
+// Rep matches m zero or more times.
+func Rep(m Matcher) Matcher {
+	return func(l *L) bool {
+		for mark := l.position; m(l) && l.position > mark; mark = l.position {
+		}
+		return true
+	}
+}
+
+// Rep1 matches m one or more times.
+func Rep1(m Matcher) Matcher {
+	return Seq(m, Rep(m))
+}
+
+// Match returns a state emitting t and returning next when m matches,
+// otherwise it reports an error and stops.
+func Match(m Matcher, t TokenType, next StateFunc) StateFunc {
+	return func(l *L) StateFunc {
+		if !m(l) {
+			l.Error(fmt.Sprintf("unexpected token %q", l.Peek()))
+			return nil
+		}
+		l.Emit(t)
+		return next
+	}
+}
+
+// rewindTo rewinds until the position mark.
+func (l *L) rewindTo(mark int) {
+	for l.position > mark && !l.rewind.empty() {
+		l.Rewind()
+	}
+}
@@ -0,0 +1,41 @@
+package lexer
+
+import (
+	"bytes"
+	"testing"
+)
+
+func Test_Matchers(t *testing.T) {
+	hex := Seq(Lit("0x"), Rep1(MatchHexDigit))
+	number := Alt(hex, Seq(Rep1(MatchDigit), Opt(Seq(Lit("."), Rep1(MatchDigit)))))
+
+	cases := []struct {
+		src   string
+		match bool
+		value string
+	}{
+		{"0x1F;", true, "0x1F"},
+		{"0xg", true, "0"},
+		{"12.5.", true, "12.5"},
+		{"12.", true, "12"},
+		{"x", false, ""},
+	}
+	for _, c := range cases {
+		l := New(bytes.NewBufferString(c.src), nil)
+		if number(l) != c.match {
+			t.Errorf("Expected match %v for %q", c.match, c.src)
+			return
+		}
+		if l.Current() != c.value {
+			t.Errorf("Expected %q but got %q", c.value, l.Current())
+			return
+		}
+	}
+
+	l := New(bytes.NewBufferString("0xff"), Match(hex, NumberToken, nil))
+	tokens, err := l.All()
+	if err != nil || len(tokens) != 1 || tokens[0].Value != "0xff" {
+		t.Errorf("Unexpected tokens %v %v", tokens, err)
+		return
+	}
+}
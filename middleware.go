@@ -0,0 +1,50 @@
+package lexer
+
+// Middleware intercepts the Next, Emit and Error calls of a lexer,
+// each func must call next to proceed. Nil funcs do not intercept.
+type Middleware struct {
+	Next  func(next func() rune) rune
+	Emit  func(t TokenType, value string, next func(t TokenType, value string))
+	Error func(e string, next func(e string))
+}
+
+// wrapped are the calls of a lexer composed with its middlewares.
+type wrapped struct {
+	next func() rune
+	emit func(t TokenType, value string)
+	err  func(e string)
+}
+
+// Wrap installs middlewares into l and returns it, the first middleware
+// is the outermost. Wrapping an already wrapped lexer adds the
+// middlewares around the existing ones. Only the calls intercepted by
+// a middleware are wrapped, the others keep their fast paths.
+func Wrap(l *L, middlewares ...Middleware) *L {
+	w := l.wrapped
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		m := middlewares[i]
+		if m.Next != nil {
+			inner := w.next
+			if inner == nil {
+				inner = l.nextRune
+			}
+			w.next = func() rune { return m.Next(inner) }
+		}
+		if m.Emit != nil {
+			inner := w.emit
+			if inner == nil {
+				inner = l.emitToken
+			}
+			w.emit = func(t TokenType, value string) { m.Emit(t, value, inner) }
+		}
+		if m.Error != nil {
+			inner := w.err
+			if inner == nil {
+				inner = l.reportError
+			}
+			w.err = func(e string) { m.Error(e, inner) }
+		}
+	}
+	l.wrapped = w
+	return l
+}
@@ -0,0 +1,50 @@
+package lexer
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func Test_Wrap(t *testing.T) {
+	var log []string
+	logger := Middleware{
+		Emit: func(t TokenType, value string, next func(TokenType, string)) {
+			log = append(log, "emit "+value)
+			next(t, value)
+		},
+		Error: func(e string, next func(string)) {
+			log = append(log, "error "+e)
+			next(e)
+		},
+	}
+	var reads int
+	upper := Middleware{
+		Next: func(next func() rune) rune {
+			reads++
+			return next()
+		},
+		Emit: func(t TokenType, value string, next func(TokenType, string)) {
+			next(t, strings.ToUpper(value))
+		},
+	}
+
+	l := Wrap(New(bytes.NewBufferString("1.ab,"), numberState), logger, upper)
+	l.ErrorHandler = func(string) {}
+	tokens, _ := l.All()
+
+	expected := "[emit 1 emit . emit ab error unexpected token ',']"
+	if fmt.Sprint(log) != expected {
+		t.Errorf("Expected %v but got %v", expected, log)
+		return
+	}
+	if len(tokens) != 3 || tokens[2].Value != "AB" {
+		t.Errorf("Unexpected tokens %v", tokens)
+		return
+	}
+	if reads == 0 {
+		t.Error("Expected the reads to be intercepted")
+		return
+	}
+}
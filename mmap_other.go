@@ -0,0 +1,12 @@
+//go:build !unix
+
+package lexer
+
+import (
+	"errors"
+	"os"
+)
+
+func mmapFile(f *os.File) (RuneSource, error) {
+	return nil, errors.New("mmap is not supported")
+}
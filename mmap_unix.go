@@ -0,0 +1,36 @@
+//go:build unix
+
+package lexer
+
+import (
+	"os"
+	"syscall"
+)
+
+type mmapSource struct {
+	bytesSource
+}
+
+func mmapFile(f *os.File) (RuneSource, error) {
+	stat, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	if stat.Size() == 0 {
+		return &mmapSource{}, nil
+	}
+	b, err := syscall.Mmap(int(f.Fd()), 0, int(stat.Size()), syscall.PROT_READ, syscall.MAP_SHARED)
+	if err != nil {
+		return nil, err
+	}
+	return &mmapSource{bytesSource{b: b}}, nil
+}
+
+func (s *mmapSource) Close() error {
+	if s.b == nil {
+		return nil
+	}
+	b := s.b
+	s.b, s.offset = nil, 0
+	return syscall.Munmap(b)
+}
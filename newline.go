@@ -0,0 +1,118 @@
+package lexer
+
+// newlines configures the significant newline mode.
+type newlines struct {
+	t        TokenType
+	suppress func(l *L) bool
+}
+
+// SignificantNewlines makes Ignore emit a token of type t for each newline of
+// the ignored value, with its position, rather than folding it into whitespace.
+// The newlines are ignored while suppress returns true, it may be nil,
+// see InDelims.
+func (l *L) SignificantNewlines(t TokenType, suppress func(l *L) bool) {
+	l.newlines = &newlines{t: t, suppress: suppress}
+}
+
+// InDelims reports whether a delimiter tracked by l is open,
+// it suppresses the newlines inside brackets, see SignificantNewlines.
+func InDelims(l *L) bool {
+	return l.delims != nil && len(l.delims.open) > 0
+}
+
+// ignoreNewlines ignores the current value, except its newlines.
+func (l *L) ignoreNewlines() {
+	n := l.position - l.start
+	for l.newlines.suppress == nil || !l.newlines.suppress(l) {
+		i := 0
+		for i < n && l.buf[l.start+i] != '\n' {
+			i++
+		}
+		if i == n {
+			break
+		}
+		l.position = l.start + i
+		l.advance()
+		l.drop()
+		l.position = 1
+		l.Emit(l.newlines.t)
+		n -= i + 1
+	}
+	l.position = l.start + n
+	l.advance()
+	l.drop()
+}
+
+// NormalizeNewlines makes l read "\r\n" and "\r" as "\n", the offsets of the
+// tokens still refer to the original bytes. It must be called before the lexing starts.
+//
+// The rune following a "\r" is read ahead only if it is available without
+// waiting, see Follow, otherwise the "\n" of a "\r\n" is counted in the size
+// of the next rune.
+func (l *L) NormalizeNewlines() {
+	l.src = &crlfSource{RuneSource: l.src}
+}
+
+// crlfSource reads the line endings of its RuneSource as "\n".
+type crlfSource struct {
+	RuneSource
+	held bool // r was read ahead from RuneSource
+	r    rune
+	size int
+	err  error
+	cr   bool // a "\r" was returned without reading ahead
+	skip int  // size of the "\n" following such a "\r", not returned yet
+}
+
+func (s *crlfSource) ReadRune() (rune, int, error) {
+	r, size, err := s.read()
+	if s.cr && err == nil && r == '\n' {
+		s.cr, s.skip = false, size
+		r, size, err = s.read()
+	}
+	if err == ErrNeedInput {
+		return r, size, err
+	}
+	s.cr = false
+	if err == nil && r == '\r' {
+		if w, ok := s.RuneSource.(waiter); ok && !w.ready() {
+			s.cr = true
+			return s.sized('\n', size, nil)
+		}
+		next, nsize, nerr := s.RuneSource.ReadRune()
+		switch {
+		case nerr == ErrNeedInput:
+			s.held, s.r, s.size, s.err = true, r, size, nil
+			return 0, 0, nerr
+		case nerr == nil && next == '\n':
+			return s.sized('\n', size+nsize, nil)
+		}
+		s.held, s.r, s.size, s.err = true, next, nsize, nerr
+		r = '\n'
+	}
+	return s.sized(r, size, err)
+}
+
+// read returns the rune read ahead, if any, or the next rune of RuneSource.
+func (s *crlfSource) read() (rune, int, error) {
+	if s.held {
+		s.held = false
+		return s.r, s.size, s.err
+	}
+	return s.RuneSource.ReadRune()
+}
+
+// sized adds the skipped "\n" to the size of r.
+func (s *crlfSource) sized(r rune, size int, err error) (rune, int, error) {
+	size, s.skip = size+s.skip, 0
+	return r, size, err
+}
+
+// Offset returns the number of bytes read so far, without the bytes read ahead.
+func (s *crlfSource) Offset() int64 {
+	n := s.RuneSource.Offset() - int64(s.skip)
+	if s.held {
+		n -= int64(s.size)
+	}
+	return n
+}
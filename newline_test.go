@@ -0,0 +1,105 @@
+package lexer
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func Test_SignificantNewlines(t *testing.T) {
+	const NewlineToken TokenType = 10
+	l, _ := delimsLexer("a\n\n (b\nc)  \nd", false)
+	l.SignificantNewlines(NewlineToken, InDelims)
+	var got []string
+	l.Scan(func(tok Token) {
+		got = append(got, fmt.Sprintf("%q@%v:%v", tok.Value, tok.Line, tok.Col))
+	})
+	expected := `["a"@1:1 "\n"@1:2 "\n"@2:1 "("@3:2 "b"@3:3 "c"@4:1 ")"@4:2 "\n"@4:5 "d"@5:1]`
+	if fmt.Sprint(got) != expected {
+		t.Errorf("Expected %v but got %v", expected, got)
+		return
+	}
+
+	l = NewString(" \n", func(l *L) StateFunc {
+		l.Take(" \n")
+		l.Ignore()
+		return nil
+	})
+	l.SignificantNewlines(NewlineToken, nil)
+	tokens, _ := l.All()
+	if len(tokens) != 1 || tokens[0].Col != 2 || tokens[0].Value != "\n" {
+		t.Errorf("Unexpected tokens %v", tokens)
+		return
+	}
+}
+
+func Test_NormalizeNewlines(t *testing.T) {
+	rules, _ := NewRules(
+		Rule{Type: IdentToken, Pattern: `[a-z]+`},
+		Rule{Type: OpToken, Pattern: `\n`},
+		Rule{Type: NumberToken, Fallback: true},
+	)
+	l := NewString("a\r\nb\rc\n\xffd", rules.State)
+	l.NormalizeNewlines()
+	var got []string
+	l.Scan(func(tok Token) {
+		got = append(got, fmt.Sprintf("%q@%v:%v:%v", tok.Value, tok.Line, tok.Col, tok.Offset))
+	})
+	expected := `["a"@1:1:0 "\n"@1:2:1 "b"@2:1:3 "\n"@2:2:4 "c"@3:1:5 "\n"@3:2:6 "�"@4:1:7 "d"@4:2:8]`
+	if fmt.Sprint(got) != expected {
+		t.Errorf("Expected %v but got %v", expected, got)
+		return
+	}
+}
+
+func Test_NormalizeNewlinesChunks(t *testing.T) {
+	rules, _ := NewRules(
+		Rule{Type: IdentToken, Pattern: `[a-z]+`},
+		Rule{Type: OpToken, Pattern: `\n`},
+	)
+	var src ChunkSource
+	l := NewSource(&src, rules.State)
+	l.NormalizeNewlines()
+	var got []string
+	f := func(tok Token) {
+		got = append(got, fmt.Sprintf("%q@%v", tok.Value, tok.Offset))
+	}
+	src.Write([]byte("a\r"))
+	l.Feed(f)
+	if src.Offset() != 2 || l.src.Offset() != 1 {
+		t.Errorf("Expected offsets %v %v but got %v %v", 2, 1, src.Offset(), l.src.Offset())
+		return
+	}
+	src.Write([]byte("\nb"))
+	src.Close()
+	l.Feed(f)
+	expected := `["a"@0 "\n"@1 "b"@3]`
+	if fmt.Sprint(got) != expected {
+		t.Errorf("Expected %v but got %v", expected, got)
+		return
+	}
+}
+
+func Test_NormalizeNewlinesFollow(t *testing.T) {
+	var b growingBuffer
+	b.WriteString("a\r")
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	l := New(Follow(ctx, &b, time.Millisecond), nil)
+	l.NormalizeNewlines()
+	for _, want := range "a\nb" {
+		if r := l.Next(); r != want {
+			t.Errorf("Expected %q but got %q", want, r)
+			return
+		}
+		if want == '\n' {
+			b.WriteString("\nb")
+		}
+	}
+	if l.ReadBytes() != 4 {
+		t.Errorf("Expected %v bytes read but got %v", 4, l.ReadBytes())
+		return
+	}
+}
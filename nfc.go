@@ -0,0 +1,83 @@
+package lexer
+
+//go:generate go run gen_nfc.go
+
+// NormalizeNFC makes l read its input in Unicode normalization form C:
+// a rune followed by combining marks is read as their canonical composition,
+// so "e\u0301" is read as "\u00e9". The offsets of the tokens still refer to
+// the original bytes. It must be called before the lexing starts.
+//
+// The marks are composed in their input order, they are expected in the
+// canonical order, and the singletons such as U+212B are not mapped.
+// The rune following a rune is read ahead only if it is available without
+// waiting, see Follow, the marks read after a wait are not composed.
+func (l *L) NormalizeNFC() {
+	l.src = &nfcSource{RuneSource: l.src}
+}
+
+// nfcSource composes the runes of its RuneSource.
+type nfcSource struct {
+	RuneSource
+	held bool // r was read ahead from RuneSource
+	r    rune
+	size int
+	err  error
+}
+
+func (s *nfcSource) ReadRune() (rune, int, error) {
+	r, size, err := s.r, s.size, s.err
+	if s.held {
+		s.held = false
+	} else {
+		r, size, err = s.RuneSource.ReadRune()
+	}
+	if err != nil {
+		return r, size, err
+	}
+	for {
+		if w, ok := s.RuneSource.(waiter); ok && !w.ready() {
+			return r, size, nil
+		}
+		next, nsize, nerr := s.RuneSource.ReadRune()
+		if nerr == ErrNeedInput {
+			s.held, s.r, s.size, s.err = true, r, size, nil
+			return 0, 0, nerr
+		}
+		c, ok := compose(r, next)
+		if nerr != nil || !ok {
+			s.held, s.r, s.size, s.err = true, next, nsize, nerr
+			return r, size, nil
+		}
+		r, size = c, size+nsize
+	}
+}
+
+// Offset returns the number of bytes read so far, without the rune read ahead.
+func (s *nfcSource) Offset() int64 {
+	if s.held {
+		return s.RuneSource.Offset() - int64(s.size)
+	}
+	return s.RuneSource.Offset()
+}
+
+const (
+	hangulBase  = 0xac00
+	hangulLBase = 0x1100
+	hangulVBase = 0x1161
+	hangulTBase = 0x11a7
+	hangulVN    = 21
+	hangulTN    = 28
+)
+
+// compose returns the canonical composition of a and b.
+func compose(a, b rune) (rune, bool) {
+	switch {
+	case a >= hangulLBase && a < hangulLBase+19 && b >= hangulVBase && b < hangulVBase+hangulVN:
+		return hangulBase + ((a-hangulLBase)*hangulVN+b-hangulVBase)*hangulTN, true
+	case a >= hangulBase && a < hangulBase+11172 && (a-hangulBase)%hangulTN == 0 &&
+		b > hangulTBase && b < hangulTBase+hangulTN:
+		return a + b - hangulTBase, true
+	}
+	c, ok := compositions[[2]rune{a, b}]
+	return c, ok
+}
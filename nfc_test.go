@@ -0,0 +1,53 @@
+package lexer
+
+import (
+	"fmt"
+	"testing"
+)
+
+func Test_NormalizeNFC(t *testing.T) {
+	rules, _ := NewRules(
+		Rule{Type: IdentToken, Pattern: `\pL+`},
+		Rule{Type: OpToken, Pattern: ` `},
+	)
+	l := NewString("cafe\u0301 caf\u00e9 \u1112\u1161\u11ab a\u0323\u0302", rules.State)
+	l.NormalizeNFC()
+	var got []string
+	l.Scan(func(tok Token) {
+		if tok.Type == IdentToken {
+			got = append(got, fmt.Sprintf("%q@%v:%v", tok.Value, tok.Col, tok.Offset))
+		}
+	})
+	expected := `["café"@1:0 "café"@6:7 "한"@11:13 "ậ"@13:23]`
+	if fmt.Sprint(got) != expected {
+		t.Errorf("Expected %v but got %v", expected, got)
+		return
+	}
+}
+
+func Test_NormalizeNFCChunks(t *testing.T) {
+	var src ChunkSource
+	l := NewSource(&src, func(l *L) StateFunc {
+		l.Next()
+		l.Emit(IdentToken)
+		return nil
+	})
+	l.NormalizeNFC()
+	var got []string
+	f := func(tok Token) {
+		got = append(got, tok.Value)
+	}
+	src.Write([]byte("e"))
+	l.Feed(f)
+	if src.Offset() != 1 || l.src.Offset() != 0 {
+		t.Errorf("Expected offsets %v %v but got %v %v", 1, 0, src.Offset(), l.src.Offset())
+		return
+	}
+	src.Write([]byte("\u0301"))
+	src.Close()
+	l.Feed(f)
+	if fmt.Sprint(got) != "[\u00e9]" {
+		t.Errorf("Expected %q but got %q", "[\u00e9]", got)
+		return
+	}
+}
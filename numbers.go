@@ -0,0 +1,167 @@
+package lexer
+
+import "fmt"
+
+// TakeDigits consumes the digits of radix, from 2 to 36, and the separators
+// sep between them, such as '_' in 1_000. It returns the number of digits
+// consumed. The misplaced separators, leading, trailing or repeated,
+// are consumed and reported by err. sep is not allowed if it is 0.
+func (l *L) TakeDigits(radix int, sep rune) (count int, err error) {
+	if radix < 2 || radix > 36 {
+		return 0, fmt.Errorf("invalid radix %v", radix)
+	}
+	afterSep := false
+	for {
+		r := l.Next()
+		switch {
+		case r != EOFRune && digitValue(r) < radix:
+			count++
+			afterSep = false
+		case r == sep && sep != 0:
+			if err == nil && (count == 0 || afterSep) {
+				err = fmt.Errorf("%q must separate successive digits", sep)
+			}
+			afterSep = true
+		default:
+			l.Rewind() // last next wasn't a match
+			if err == nil && afterSep {
+				err = fmt.Errorf("%q must separate successive digits", sep)
+			}
+			return count, err
+		}
+	}
+}
+
+// digitValue returns the value of the digit r, or 36 if r is not a digit.
+func digitValue(r rune) int {
+	switch {
+	case r >= '0' && r <= '9':
+		return int(r - '0')
+	case r >= 'a' && r <= 'z':
+		return int(r-'a') + 10
+	case r >= 'A' && r <= 'Z':
+		return int(r-'A') + 10
+	}
+	return 36
+}
+
+// NumberKind is the kind of a number scanned by ScanFloat.
+type NumberKind int
+
+const (
+	IntNumber      NumberKind = iota // 42
+	FloatNumber                      // 4.2, .5, 42e-1
+	HexIntNumber                     // 0x2A
+	HexFloatNumber                   // 0x1.8p3
+)
+
+// FloatScanner consumes the integer and floating point numbers.
+type FloatScanner struct {
+	// Sign accepts a leading '+' or '-'.
+	Sign bool
+	// Sep is the digit separator, see TakeDigits, none if it is 0.
+	Sep rune
+	// Hex accepts the hexadecimal numbers, prefixed with 0x.
+	Hex bool
+}
+
+// ScanFloat consumes a decimal or hexadecimal number, unsigned, without
+// separator. See FloatScanner.Scan.
+func ScanFloat(l *L) (kind NumberKind, ok bool) {
+	return FloatScanner{Hex: true}.Scan(l)
+}
+
+// Scan consumes a number and returns its kind, it emits nothing.
+// If the input does not start with a number nothing is consumed and ok is false.
+// If the number is malformed, such as 1e+ or 0x1.8, an error is reported
+// with Error, ok is false and the malformed value stays consumed.
+func (s FloatScanner) Scan(l *L) (kind NumberKind, ok bool) {
+	mark := l.position
+	if s.Sign && (l.Peek() == '+' || l.Peek() == '-') {
+		l.Next()
+	}
+	if s.Hex && (l.AcceptString("0x") || l.AcceptString("0X")) {
+		return s.scanHex(l)
+	}
+	n, err := l.TakeDigits(10, s.Sep)
+	point := l.Peek() == '.'
+	if point {
+		l.Next()
+		var m int
+		if m, err = s.takeDigits(l, 10, err); n+m == 0 {
+			l.rewindTo(mark)
+			return 0, false
+		}
+	} else if n == 0 {
+		l.rewindTo(mark)
+		return 0, false
+	}
+	kind = IntNumber
+	if point {
+		kind = FloatNumber
+	}
+	if r := l.Peek(); r == 'e' || r == 'E' {
+		l.Next()
+		kind = FloatNumber
+		if eerr := s.exponent(l); err == nil {
+			err = eerr
+		}
+	}
+	return kind, s.check(l, err)
+}
+
+func (s FloatScanner) scanHex(l *L) (kind NumberKind, ok bool) {
+	n, err := l.TakeDigits(16, s.Sep)
+	point := l.Peek() == '.'
+	if point {
+		l.Next()
+		var m int
+		m, err = s.takeDigits(l, 16, err)
+		n += m
+	}
+	if err == nil && n == 0 {
+		err = fmt.Errorf("hexadecimal number %q has no digits", l.Current())
+	}
+	kind = HexIntNumber
+	if r := l.Peek(); r == 'p' || r == 'P' {
+		l.Next()
+		kind = HexFloatNumber
+		if eerr := s.exponent(l); err == nil {
+			err = eerr
+		}
+	} else if point && err == nil {
+		err = fmt.Errorf("hexadecimal mantissa %q requires a 'p' exponent", l.Current())
+	}
+	return kind, s.check(l, err)
+}
+
+// takeDigits consumes the digits of radix, it returns the first of err
+// and the digits error.
+func (s FloatScanner) takeDigits(l *L, radix int, err error) (int, error) {
+	n, derr := l.TakeDigits(radix, s.Sep)
+	if err == nil {
+		err = derr
+	}
+	return n, err
+}
+
+// exponent consumes the signed decimal exponent following its marker.
+func (s FloatScanner) exponent(l *L) error {
+	if l.Peek() == '+' || l.Peek() == '-' {
+		l.Next()
+	}
+	n, err := l.TakeDigits(10, s.Sep)
+	if err == nil && n == 0 {
+		err = fmt.Errorf("exponent of %q has no digits", l.Current())
+	}
+	return err
+}
+
+// check reports err, if any, and returns whether the number is valid.
+func (s FloatScanner) check(l *L, err error) bool {
+	if err != nil {
+		l.Error(err.Error())
+		return false
+	}
+	return true
+}
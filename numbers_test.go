@@ -0,0 +1,80 @@
+package lexer
+
+import (
+	"fmt"
+	"testing"
+)
+
+func Test_TakeDigits(t *testing.T) {
+	for _, c := range []struct {
+		src   string
+		radix int
+		sep   rune
+		value string
+		count int
+		err   bool
+	}{
+		{"1_000_000+", 10, '_', "1_000_000", 7, false},
+		{"ff_FFg", 16, '_', "ff_FF", 4, false},
+		{"1012", 2, 0, "101", 3, false},
+		{"1_2", 10, 0, "1", 1, false},
+		{"x", 10, '_', "", 0, false},
+		{"_1", 10, '_', "_1", 1, true},
+		{"1__2", 10, '_', "1__2", 2, true},
+		{"1_ ", 10, '_', "1_", 1, true},
+		{"z9", 36, '\'', "z9", 2, false},
+		{"1", 37, '_', "", 0, true},
+	} {
+		l := NewString(c.src, nil)
+		count, err := l.TakeDigits(c.radix, c.sep)
+		got := fmt.Sprintf("%q %v %v", l.Current(), count, err != nil)
+		expected := fmt.Sprintf("%q %v %v", c.value, c.count, c.err)
+		if got != expected {
+			t.Errorf("Expected %v but got %v for %q", expected, got, c.src)
+			return
+		}
+	}
+}
+
+func Test_ScanFloat(t *testing.T) {
+	for _, c := range []struct {
+		src   string
+		value string
+		kind  NumberKind
+		err   string
+	}{
+		{"42 ", "42", IntNumber, ""},
+		{"4.2e-1,", "4.2e-1", FloatNumber, ""},
+		{".5)", ".5", FloatNumber, ""},
+		{"5.", "5.", FloatNumber, ""},
+		{"1E9", "1E9", FloatNumber, ""},
+		{"0x2Ag", "0x2A", HexIntNumber, ""},
+		{"0x1.8p-3", "0x1.8p-3", HexFloatNumber, ""},
+		{"-1", "", 0, ""},
+		{".x", "", 0, ""},
+		{"1e+;", "1e+", FloatNumber, `exponent of "1e+" has no digits`},
+		{"0x1.8", "0x1.8", HexIntNumber, `hexadecimal mantissa "0x1.8" requires a 'p' exponent`},
+		{"0xp1", "0xp1", HexFloatNumber, `hexadecimal number "0x" has no digits`},
+	} {
+		l := NewString(c.src, nil)
+		var errs []string
+		l.ErrorHandler = func(e string) {
+			errs = append(errs, e)
+		}
+		kind, ok := ScanFloat(l)
+		got := fmt.Sprintf("%q %v %v %q", l.Current(), kind, ok, errs)
+		expected := fmt.Sprintf("%q %v %v %q", c.value, c.kind, c.value != "" && c.err == "", []string{})
+		if c.err != "" {
+			expected = fmt.Sprintf("%q %v %v %q", c.value, c.kind, false, []string{c.err})
+		}
+		if got != expected {
+			t.Errorf("Expected %v but got %v", expected, got)
+			return
+		}
+	}
+	l := NewString("-1_000.5", nil)
+	if kind, ok := (FloatScanner{Sign: true, Sep: '_'}).Scan(l); !ok || kind != FloatNumber || l.Current() != "-1_000.5" {
+		t.Errorf("Expected %q but got %q", "-1_000.5", l.Current())
+		return
+	}
+}
@@ -0,0 +1,73 @@
+package lexer
+
+// OperatorSet is a compiled set of operators, it finds the longest operator
+// at the current position in one pass over the input.
+type OperatorSet struct {
+	root opNode
+}
+
+type opNode struct {
+	children map[rune]*opNode
+	terminal bool
+	t        TokenType
+}
+
+// NewOperatorSet compiles ops, a map of operators to their token type.
+func NewOperatorSet(ops map[string]TokenType) *OperatorSet {
+	o := &OperatorSet{}
+	for op, t := range ops {
+		n := &o.root
+		for _, r := range op {
+			if n.children == nil {
+				n.children = map[rune]*opNode{}
+			}
+			child, ok := n.children[r]
+			if !ok {
+				child = &opNode{}
+				n.children[r] = child
+			}
+			n = child
+		}
+		if n != &o.root {
+			n.terminal, n.t = true, t
+		}
+	}
+	return o
+}
+
+// Accept consumes the longest operator at the current position
+// and returns its type, it returns false if none matches.
+func (o *OperatorSet) Accept(l *L) (TokenType, bool) {
+	var (
+		t       TokenType
+		n       = &o.root
+		read    int
+		longest int
+	)
+	for {
+		r := l.Next()
+		read++
+		child, ok := n.children[r]
+		if !ok {
+			break
+		}
+		n = child
+		if n.terminal {
+			longest, t = read, n.t
+		}
+	}
+	for ; read > longest; read-- {
+		l.Rewind()
+	}
+	return t, longest > 0
+}
+
+// Emit consumes and emits the longest operator at the current position,
+// it returns false if none matches.
+func (o *OperatorSet) Emit(l *L) bool {
+	t, ok := o.Accept(l)
+	if ok {
+		l.Emit(t)
+	}
+	return ok
+}
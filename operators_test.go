@@ -0,0 +1,78 @@
+package lexer
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+)
+
+func Test_OperatorSet(t *testing.T) {
+	const (
+		AssignToken TokenType = iota + 10
+		EqToken
+		ArrowToken
+		ShlAssignToken
+		LtToken
+		EllipsisToken
+	)
+	ops := NewOperatorSet(map[string]TokenType{
+		"=":   AssignToken,
+		"==":  EqToken,
+		"=>":  ArrowToken,
+		"<<=": ShlAssignToken,
+		"<":   LtToken,
+		"...": EllipsisToken,
+	})
+
+	var state StateFunc
+	state = func(l *L) StateFunc {
+		if !ops.Emit(l) {
+			return nil
+		}
+		return state
+	}
+	l := New(bytes.NewBufferString("===><<<=..<"), state)
+	tokens, err := l.All()
+	if err != nil {
+		t.Errorf("Unexpected error %v", err)
+		return
+	}
+	var got []string
+	for _, tok := range tokens {
+		got = append(got, fmt.Sprintf("%v:%v", tok.Type, tok.Value))
+	}
+	expected := "[11:== 12:=> 14:< 13:<<=]"
+	if fmt.Sprint(got) != expected {
+		t.Errorf("Expected %v but got %v", expected, got)
+		return
+	}
+	if l.Current() != "" || l.Next() != '.' {
+		t.Errorf("Expected the unmatched input to be rewound, got %q", l.Current())
+		return
+	}
+}
+
+func Test_TakeLongest(t *testing.T) {
+	ops := []string{"=", "==", "=>", "<"}
+	types := []TokenType{10, 11, 12, 13}
+
+	l := New(bytes.NewBufferString("===>!"), nil)
+	var tokens []Token
+	l.TokenHandler = func(tok Token) {
+		tokens = append(tokens, tok)
+	}
+	for l.TakeLongest(ops, types) {
+	}
+	expected := []Token{
+		{Type: 11, Value: "==", Line: 1, Col: 1, Offset: 0, Index: 0},
+		{Type: 12, Value: "=>", Line: 1, Col: 3, Offset: 2, Index: 1},
+	}
+	if fmt.Sprint(tokens) != fmt.Sprint(expected) {
+		t.Errorf("Expected %v but got %v", expected, tokens)
+		return
+	}
+	if l.Current() != "" || l.Next() != '!' {
+		t.Errorf("Expected the unmatched input to be rewound, got %q", l.Current())
+		return
+	}
+}
@@ -0,0 +1,82 @@
+package lexer
+
+import "bufio"
+
+// Option configures a lexer at its creation, see New.
+type Option func(l *L)
+
+// WithReadBuffer reads the io.Reader of the lexer through a buffer of size
+// bytes, it has no effect if the reader is already an io.RuneReader.
+func WithReadBuffer(size int) Option {
+	return func(l *L) {
+		if rs, ok := l.src.(*readerSource); ok && rs.rr == nil {
+			br := bufio.NewReaderSize(rs.r, size)
+			rs.r, rs.rr = br, br
+		}
+	}
+}
+
+// WithMaxBuffer sets MaxBuffer.
+func WithMaxBuffer(runes int) Option {
+	return func(l *L) { l.MaxBuffer = runes }
+}
+
+// WithMaxErrors sets MaxErrors.
+func WithMaxErrors(n int) Option {
+	return func(l *L) { l.MaxErrors = n }
+}
+
+// WithColumns sets the unit of the columns.
+func WithColumns(unit ColumnUnit) Option {
+	return func(l *L) { l.Columns = unit }
+}
+
+// WithBaseOffset sets the base offset, see SetBaseOffset.
+func WithBaseOffset(offset int) Option {
+	return func(l *L) { l.SetBaseOffset(offset) }
+}
+
+// WithNormalizedNewlines reads "\r\n" and "\r" as "\n", see NormalizeNewlines.
+func WithNormalizedNewlines() Option {
+	return func(l *L) { l.NormalizeNewlines() }
+}
+
+// WithErrorHandler sets ErrorHandler, the lexer panics on errors without it.
+func WithErrorHandler(f func(e string)) Option {
+	return func(l *L) { l.ErrorHandler = f }
+}
+
+// WithWarningHandler sets WarningHandler.
+func WithWarningHandler(f func(e *LexError)) Option {
+	return func(l *L) { l.WarningHandler = f }
+}
+
+// WithMiddleware installs middlewares, see Wrap.
+func WithMiddleware(middlewares ...Middleware) Option {
+	return func(l *L) { Wrap(l, middlewares...) }
+}
+
+// WithOnEmit registers f to be invoked with each emitted token, see OnEmit.
+func WithOnEmit(f func(t Token)) Option {
+	return func(l *L) { l.OnEmit(f) }
+}
+
+// WithIntern shares the values of the emitted tokens in t.
+func WithIntern(t *InternTable) Option {
+	return func(l *L) { l.Intern = t }
+}
+
+// WithUnicodeFold folds the cases of all unicode letters, see UnicodeFold.
+func WithUnicodeFold() Option {
+	return func(l *L) { l.UnicodeFold = true }
+}
+
+// WithProvenance stamps the tokens with the name of their state.
+func WithProvenance() Option {
+	return func(l *L) { l.Provenance = true }
+}
+
+// WithIncluder sets the Includer resolving the sources of Include.
+func WithIncluder(i Includer) Option {
+	return func(l *L) { l.Includer = i }
+}
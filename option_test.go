@@ -0,0 +1,40 @@
+package lexer
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func Test_Options(t *testing.T) {
+	var errs, emitted []string
+	l := New(strings.NewReader("12.ab\r\n3.x#"), numberState,
+		WithReadBuffer(16),
+		WithNormalizedNewlines(),
+		WithBaseOffset(100),
+		WithErrorHandler(func(e string) { errs = append(errs, e) }),
+		WithOnEmit(func(t Token) { emitted = append(emitted, t.Value) }),
+	)
+	var got []string
+	l.Scan(func(tok Token) {
+		got = append(got, fmt.Sprintf("%v@%v", tok.Value, tok.Offset))
+	})
+	expected := "[12@100 .@102 ab@103 3@107 .@108 x@109]"
+	if fmt.Sprint(got) != expected {
+		t.Errorf("Expected %v but got %v", expected, got)
+		return
+	}
+	if fmt.Sprint(errs) != `[unexpected token '#']` || fmt.Sprint(emitted) != "[12 . ab 3 . x]" {
+		t.Errorf("Expected %v but got %v %v", "[12 . ab 3 . x]", errs, emitted)
+		return
+	}
+}
+
+func Test_OptionsLimits(t *testing.T) {
+	l := NewString("a b c", whitespaceState, WithMaxErrors(2), WithMaxBuffer(8),
+		WithColumns(ColumnBytes), WithUnicodeFold(), WithProvenance())
+	if l.MaxErrors != 2 || l.MaxBuffer != 8 || l.Columns != ColumnBytes || !l.UnicodeFold || !l.Provenance {
+		t.Errorf("Expected the options to be applied but got %+v", l)
+		return
+	}
+}
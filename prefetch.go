@@ -0,0 +1,88 @@
+package lexer
+
+import (
+	"io"
+	"sync"
+)
+
+// prefetchBuffers is the number of chunks of the ring of a prefetcher.
+const prefetchBuffers = 4
+
+// Prefetch returns a reader of r filled ahead by a goroutine, it reads up to
+// 4 chunks of size bytes while the lexer consumes the previous ones,
+// hiding the read latency of network or disk sources.
+// It must be closed to stop the goroutine if r is not read until its end.
+func Prefetch(r io.Reader, size int) io.ReadCloser {
+	p := &prefetcher{
+		chunks: make(chan prefetched, prefetchBuffers),
+		free:   make(chan []byte, prefetchBuffers),
+		done:   make(chan struct{}),
+	}
+	for i := 0; i < prefetchBuffers; i++ {
+		p.free <- make([]byte, size)
+	}
+	go p.fill(r)
+	return p
+}
+
+// prefetched is a chunk read by a prefetcher.
+type prefetched struct {
+	buf []byte
+	n   int
+	err error
+}
+
+type prefetcher struct {
+	chunks chan prefetched
+	free   chan []byte
+	done   chan struct{}
+	once   sync.Once
+	cur    prefetched
+	off    int
+}
+
+func (p *prefetcher) fill(r io.Reader) {
+	for {
+		var buf []byte
+		select {
+		case buf = <-p.free:
+		case <-p.done:
+			return
+		}
+		n, err := r.Read(buf)
+		select {
+		case p.chunks <- prefetched{buf: buf, n: n, err: err}:
+		case <-p.done:
+			return
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+func (p *prefetcher) Read(b []byte) (int, error) {
+	for p.off == p.cur.n {
+		if p.cur.err != nil {
+			return 0, p.cur.err
+		}
+		if p.cur.buf != nil {
+			p.free <- p.cur.buf
+		}
+		select {
+		case p.cur = <-p.chunks:
+			p.off = 0
+		case <-p.done:
+			return 0, io.ErrClosedPipe
+		}
+	}
+	n := copy(b, p.cur.buf[p.off:p.cur.n])
+	p.off += n
+	return n, nil
+}
+
+// Close stops the prefetching goroutine.
+func (p *prefetcher) Close() error {
+	p.once.Do(func() { close(p.done) })
+	return nil
+}
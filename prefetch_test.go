@@ -0,0 +1,33 @@
+package lexer
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func Test_Prefetch(t *testing.T) {
+	src := strings.Repeat("12.abc ", 1000)
+	r := Prefetch(iotest.HalfReader(strings.NewReader(src)), 64)
+	defer r.Close()
+
+	tokens, err := New(r, numberState).All()
+	if err != nil {
+		t.Errorf("Unexpected error %v", err)
+		return
+	}
+	expected, _ := New(strings.NewReader(src), numberState).All()
+	if fmt.Sprint(tokens) != fmt.Sprint(expected) {
+		t.Errorf("Expected %v tokens but got %v", len(expected), len(tokens))
+		return
+	}
+
+	r = Prefetch(strings.NewReader(src), 64)
+	r.Close()
+	if _, err := io.ReadAll(r); err != io.ErrClosedPipe {
+		t.Errorf("Expected %v but got %v", io.ErrClosedPipe, err)
+		return
+	}
+}
@@ -0,0 +1,102 @@
+package lexer
+
+import (
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// SourceMap builds the output of a text preprocessor, such as a macro
+// expander or a conditional compiler, and maps its offsets back to the
+// original source. The lexers created with New locate their tokens and
+// their errors in the original source.
+type SourceMap struct {
+	src      string
+	lines    []int // offsets of the line starts of src
+	out      strings.Builder
+	segments []segment
+}
+
+// segment is a part of the output, copied from the original source
+// or generated at an original offset.
+type segment struct {
+	out, orig int
+	generated bool
+}
+
+// NewSourceMap creates a SourceMap with an empty output for src.
+func NewSourceMap(src string) *SourceMap {
+	m := &SourceMap{src: src, lines: []int{0}}
+	for i := 0; i < len(src); i++ {
+		if src[i] == '\n' {
+			m.lines = append(m.lines, i+1)
+		}
+	}
+	return m
+}
+
+// Copy appends the original source from offset from to offset to.
+func (m *SourceMap) Copy(from, to int) {
+	m.segments = append(m.segments, segment{out: m.out.Len(), orig: from})
+	m.out.WriteString(m.src[from:to])
+}
+
+// Insert appends the generated text, its offsets are mapped to the original
+// offset at, such as the location of the expanded macro.
+func (m *SourceMap) Insert(text string, at int) {
+	m.segments = append(m.segments, segment{out: m.out.Len(), orig: at, generated: true})
+	m.out.WriteString(text)
+}
+
+// String returns the output.
+func (m *SourceMap) String() string {
+	return m.out.String()
+}
+
+// Original returns the original offset of the output offset off.
+func (m *SourceMap) Original(off int) int {
+	i := sort.Search(len(m.segments), func(i int) bool {
+		return m.segments[i].out > off
+	}) - 1
+	if i < 0 {
+		return off
+	}
+	s := m.segments[i]
+	if s.generated {
+		return s.orig
+	}
+	return s.orig + off - s.out
+}
+
+// Position returns the line and the column, in runes, of the original offset off.
+func (m *SourceMap) Position(off int) (line, col int) {
+	i := sort.SearchInts(m.lines, off+1) - 1
+	return i + 1, 1 + utf8.RuneCountInString(m.src[m.lines[i]:off])
+}
+
+// New creates a returns a lexer ready to parse the output with start.
+func (m *SourceMap) New(start StateFunc) *L {
+	l := NewString(m.String(), start)
+	l.sourceMap = m
+	return l
+}
+
+// locate moves tok to its original position.
+func (m *SourceMap) locate(tok *Token) {
+	tok.Offset = m.Original(tok.Offset)
+	tok.Line, tok.Col = m.Position(tok.Offset)
+}
+
+// locateError moves e, whose value spans the output offsets from and to,
+// to its original position.
+func (m *SourceMap) locateError(e *LexError, from, to int) {
+	from = m.Original(from)
+	e.Line, e.Col = m.Position(from)
+	e.EndLine, e.EndCol = m.Position(max(from, m.Original(to)))
+	start := m.lines[e.Line-1]
+	end := strings.IndexByte(m.src[start:], '\n')
+	if end < 0 {
+		end = len(m.src) - start
+	}
+	e.LineText = m.src[start : start+end]
+}
@@ -0,0 +1,51 @@
+package lexer
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func Test_SourceMap(t *testing.T) {
+	src := "#define N 42\nx = N + N ?"
+	m := NewSourceMap(src)
+	body := strings.IndexByte(src, '\n') + 1
+	pos := body
+	for i := body; i < len(src); i++ {
+		if src[i] == 'N' {
+			m.Copy(pos, i)
+			m.Insert("42", i)
+			pos = i + 1
+		}
+	}
+	m.Copy(pos, len(src))
+	if m.String() != "x = 42 + 42 ?" {
+		t.Errorf("Expected %q but got %q", "x = 42 + 42 ?", m.String())
+		return
+	}
+	rules, _ := NewRules(
+		Rule{Type: IdentToken, Pattern: `[a-z]+`},
+		Rule{Type: NumberToken, Pattern: `[0-9]+`},
+		Rule{Type: OpToken, Pattern: `[=+]`},
+		Rule{Pattern: `\s+`, Skip: true},
+	)
+	l := m.New(rules.State)
+	var errs []*LexError
+	l.ErrorHandler = func(string) {}
+	l.OnError(func(e *LexError) {
+		errs = append(errs, e)
+	})
+	var got []string
+	l.Scan(func(tok Token) {
+		got = append(got, fmt.Sprintf("%v@%v:%v:%v", tok.Value, tok.Line, tok.Col, tok.Offset))
+	})
+	expected := "[x@2:1:13 =@2:3:15 42@2:5:17 +@2:7:19 42@2:9:21]"
+	if fmt.Sprint(got) != expected {
+		t.Errorf("Expected %v but got %v", expected, got)
+		return
+	}
+	if len(errs) != 1 || errs[0].Line != 2 || errs[0].Col != 11 || errs[0].LineText != "x = N + N ?" {
+		t.Errorf("Expected an error at 2:11 but got %v", errs)
+		return
+	}
+}
@@ -0,0 +1,98 @@
+package lexer
+
+import (
+	"context"
+	"reflect"
+	"runtime"
+	"runtime/pprof"
+	"strings"
+	"time"
+)
+
+// StateHook receives the name and the duration of each state execution.
+type StateHook func(state string, elapsed time.Duration)
+
+// StateName returns the name of the function s, such as lexer.NumberState.
+func StateName(s StateFunc) string {
+	if s == nil {
+		return ""
+	}
+	name := runtime.FuncForPC(reflect.ValueOf(s).Pointer()).Name()
+	if i := strings.LastIndexByte(name, '/'); i > -1 {
+		name = name[i+1:]
+	}
+	return name
+}
+
+// instrumented is true if run has to identify the states.
+func (l *L) instrumented() bool {
+	return l.PprofLabels || l.StateHook != nil || l.Provenance || l.Trace ||
+		l.recorder != nil || l.replay != nil || len(l.hooks.state) > 0
+}
+
+// run executes state, it continues with the next state of a Chain,
+// and stops the lexing once MaxErrors is reached.
+// At the end, it invokes the OnEnd hooks, and in the lossless mode,
+// the input left is emitted.
+func (l *L) run(state StateFunc) StateFunc {
+	err := l.Err
+	next := l.exec(state)
+	if next == nil && len(l.chained) > 0 {
+		next = l.chainNext(l.Err != err)
+	}
+	if l.MaxErrors > 0 && l.errors >= l.MaxErrors || l.overflow {
+		next = nil
+	}
+	if next == nil {
+		for _, f := range l.hooks.end {
+			f()
+		}
+	}
+	if next == nil && l.lossless {
+		l.skipRest()
+	}
+	if next == nil && len(l.replay) > 0 {
+		l.replayMissing()
+	}
+	return next
+}
+
+// exec executes state, with the pprof labels, the StateHook,
+// the provenance, the trace, the hooks and the recording, if enabled.
+func (l *L) exec(state StateFunc) StateFunc {
+	l.current = state
+	if !l.instrumented() {
+		return state(l)
+	}
+	name := StateName(state)
+	l.stateName = name
+	if l.recorder != nil {
+		l.recorder.state(name)
+	}
+	if l.replay != nil {
+		l.replayed(RecordEvent{State: name})
+	}
+	start := time.Now()
+	var next StateFunc
+	if l.PprofLabels {
+		labels := pprof.Labels("lexer", l.Name, "state", name)
+		pprof.Do(context.Background(), labels, func(context.Context) {
+			next = state(l)
+		})
+	} else {
+		next = state(l)
+	}
+	if l.StateHook != nil {
+		l.StateHook(name, time.Since(start))
+	}
+	if l.Trace || len(l.hooks.state) > 0 {
+		to := StateName(next)
+		if l.Trace {
+			l.trace(name, to)
+		}
+		for _, f := range l.hooks.state {
+			f(name, to)
+		}
+	}
+	return next
+}
@@ -0,0 +1,50 @@
+package lexer
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_StateHook(t *testing.T) {
+	l := New(bytes.NewBufferString("123.hello"), numberState)
+	l.Name = "test"
+	l.PprofLabels = true
+
+	var states []string
+	l.StateHook = func(state string, elapsed time.Duration) {
+		states = append(states, state)
+	}
+	l.Scan(func(Token) {})
+
+	expected := []string{".numberState", ".identState", ".whitespaceState"}
+	if len(states) != len(expected) {
+		t.Errorf("Expected %v states but got %v", len(expected), states)
+		return
+	}
+	for i, name := range expected {
+		if !strings.HasSuffix(states[i], name) {
+			t.Errorf("Expected %q but got %q", name, states[i])
+			return
+		}
+	}
+}
+
+func Test_Provenance(t *testing.T) {
+	l := New(bytes.NewBufferString("123.hello"), numberState)
+	l.Provenance = true
+	tokens, _ := l.All()
+
+	expected := []string{".numberState", ".numberState", ".identState"}
+	if len(tokens) != len(expected) {
+		t.Errorf("Expected %v tokens but got %v", len(expected), len(tokens))
+		return
+	}
+	for i, name := range expected {
+		if !strings.HasSuffix(tokens[i].State, name) {
+			t.Errorf("Expected %q but got %q", name, tokens[i].State)
+			return
+		}
+	}
+}
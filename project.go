@@ -0,0 +1,126 @@
+package lexer
+
+import (
+	"runtime"
+	"sync"
+)
+
+// FileResult is the lexing result of a file of a Project.
+type FileResult struct {
+	Path        string
+	Tokens      []Token
+	Diagnostics Diagnostics
+	// Err is the error opening the file or the lexer error, see L.Err.
+	Err error
+}
+
+// Project lexes many files concurrently with the same states.
+type Project struct {
+	// Start is the initial state of the lexers.
+	Start StateFunc
+	// Setup configures the lexer of each file before it runs, it may be nil.
+	// The lexers report their errors as diagnostics, unless Setup sets
+	// another ErrorHandler.
+	Setup func(path string, l *L)
+	// Workers is the number of files lexed at once, runtime.NumCPU() if zero.
+	Workers int
+	// Progress is invoked after each file with the number of files done,
+	// it may be nil. The calls are serialized.
+	Progress func(done, total int)
+}
+
+// Lex lexes the files at paths and returns their results, in the order of paths.
+func (p *Project) Lex(paths []string) []FileResult {
+	results := make([]FileResult, len(paths))
+	workers := p.Workers
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		done int
+		jobs = make(chan int)
+	)
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				results[i] = p.lexFile(paths[i])
+				if p.Progress != nil {
+					mu.Lock()
+					done++
+					p.Progress(done, len(paths))
+					mu.Unlock()
+				}
+			}
+		}()
+	}
+	for i := range paths {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+	return results
+}
+
+func (p *Project) lexFile(path string) FileResult {
+	res := FileResult{Path: path}
+	l, err := NewFromFile(path, p.Start)
+	if err != nil {
+		res.Err = err
+		return res
+	}
+	defer l.Close()
+	l.ErrorHandler = func(string) {}
+	if p.Setup != nil {
+		p.Setup(path, l)
+	}
+	res.Tokens, res.Err = l.All()
+	res.Diagnostics = l.Diagnostics()
+	for _, d := range res.Diagnostics {
+		if d.File == "" {
+			d.File = path
+		}
+	}
+	return res
+}
+
+// Aggregate returns the diagnostics of all results, ordered by file and position.
+func Aggregate(results []FileResult) Diagnostics {
+	var ret Diagnostics
+	for _, r := range results {
+		ret = append(ret, r.Diagnostics...)
+	}
+	ret.Sort()
+	return ret
+}
+
+// Within returns the diagnostics of r located in a token of one of types,
+// such as the errors inside strings or comments.
+func (r FileResult) Within(types ...TokenType) Diagnostics {
+	return r.Diagnostics.Filter(func(d *Diagnostic) bool {
+		for _, t := range r.Tokens {
+			if t.Line > d.Line || t.Line == d.Line && t.Col > d.Col {
+				break
+			}
+			line, col := tokenEnd(t, ColumnRunes)
+			inside := t.Line == d.Line && t.Col == d.Col ||
+				d.Line < line || d.Line == line && d.Col < col
+			if inside && containsType(types, t.Type) {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+func containsType(types []TokenType, t TokenType) bool {
+	for _, typ := range types {
+		if typ == t {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,93 @@
+package lexer
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func Test_Project(t *testing.T) {
+	dir := t.TempDir()
+	var paths []string
+	for i, src := range []string{"a b", "c 1", "d e f"} {
+		path := filepath.Join(dir, fmt.Sprintf("%d.txt", i))
+		os.WriteFile(path, []byte(src), 0o644)
+		paths = append(paths, path)
+	}
+	paths = append(paths, filepath.Join(dir, "missing.txt"))
+	rules, _ := NewRules(
+		Rule{Type: IdentToken, Pattern: `[a-z]+`},
+		Rule{Pattern: `\s+`, Skip: true},
+	)
+	var progress []int
+	p := &Project{
+		Start:    rules.State,
+		Workers:  2,
+		Progress: func(done, total int) { progress = append(progress, done, total) },
+	}
+	results := p.Lex(paths)
+	var got []string
+	for _, r := range results {
+		got = append(got, fmt.Sprintf("%v:%v:%v:%v", filepath.Base(r.Path), len(r.Tokens), len(r.Diagnostics), r.Err != nil))
+	}
+	expected := "[0.txt:2:0:false 1.txt:1:1:true 2.txt:3:0:false missing.txt:0:0:true]"
+	if fmt.Sprint(got) != expected {
+		t.Errorf("Expected %v but got %v", expected, got)
+		return
+	}
+	if fmt.Sprint(progress) != "[1 4 2 4 3 4 4 4]" {
+		t.Errorf("Expected %v but got %v", "[1 4 2 4 3 4 4 4]", progress)
+		return
+	}
+}
+
+func Test_Aggregate(t *testing.T) {
+	dir := t.TempDir()
+	var paths []string
+	for i, src := range []string{"a x y\nx", "y a"} {
+		path := filepath.Join(dir, fmt.Sprintf("%d.txt", i))
+		os.WriteFile(path, []byte(src), 0o644)
+		paths = append(paths, path)
+	}
+	var state StateFunc
+	state = func(l *L) StateFunc {
+		switch l.Next() {
+		case EOFRune:
+			return nil
+		case ' ', '\n':
+			l.Ignore()
+		case 'x':
+			l.Warnf("x")
+			l.Emit(OpToken)
+		case 'y':
+			l.Error("y")
+			l.Emit(IdentToken)
+		default:
+			l.Emit(IdentToken)
+		}
+		return state
+	}
+	results := (&Project{Start: state}).Lex(paths)
+	var got []string
+	for _, d := range Aggregate(results) {
+		got = append(got, fmt.Sprintf("%v:%v:%v:%v", filepath.Base(d.File), d.Line, d.Col, d.Severity))
+	}
+	expected := "[0.txt:1:3:warning 0.txt:1:5:error 0.txt:2:1:warning 1.txt:1:1:error]"
+	if fmt.Sprint(got) != expected {
+		t.Errorf("Expected %v but got %v", expected, got)
+		return
+	}
+	if n := len(Aggregate(results).InFile(paths[1])); n != 1 {
+		t.Errorf("Expected %v but got %v", 1, n)
+		return
+	}
+	if n := len(Aggregate(results).AtLeast(SeverityError)); n != 2 {
+		t.Errorf("Expected %v but got %v", 2, n)
+		return
+	}
+	if n := len(results[0].Within(OpToken)); n != 2 {
+		t.Errorf("Expected %v but got %v", 2, n)
+		return
+	}
+}
@@ -0,0 +1,134 @@
+package lexer
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// protobuf wire encoding of the messages defined in token.proto.
+
+const (
+	protoVarint   = 0
+	protoFixed64  = 1
+	protoBytes    = 2
+	protoFixed32  = 5
+	protoTokType  = 1
+	protoTokName  = 2
+	protoTokValue = 3
+	protoTokSpan  = 4
+	protoTokIndex = 5
+	protoSpanLine = 1
+	protoSpanCol  = 2
+	protoSpanOff  = 3
+)
+
+var errProtoTruncated = errors.New("truncated protobuf message")
+
+func appendProtoVarint(b []byte, field int, v uint64) []byte {
+	if v == 0 {
+		return b
+	}
+	b = binary.AppendUvarint(b, uint64(field<<3|protoVarint))
+	return binary.AppendUvarint(b, v)
+}
+
+func appendProtoBytes(b []byte, field int, v []byte) []byte {
+	if len(v) == 0 {
+		return b
+	}
+	b = binary.AppendUvarint(b, uint64(field<<3|protoBytes))
+	b = binary.AppendUvarint(b, uint64(len(v)))
+	return append(b, v...)
+}
+
+// appendProtoToken appends the Token message of t to b.
+func appendProtoToken(b []byte, t Token, name string) []byte {
+	var span []byte
+	span = appendProtoVarint(span, protoSpanLine, uint64(t.Line))
+	span = appendProtoVarint(span, protoSpanCol, uint64(t.Col))
+	span = appendProtoVarint(span, protoSpanOff, uint64(t.Offset))
+
+	b = appendProtoVarint(b, protoTokType, uint64(int64(t.Type)))
+	b = appendProtoBytes(b, protoTokName, []byte(name))
+	b = appendProtoBytes(b, protoTokValue, []byte(t.Value))
+	b = appendProtoBytes(b, protoTokSpan, span)
+	return appendProtoVarint(b, protoTokIndex, uint64(t.Index))
+}
+
+// parseProtoToken decodes the Token message b and the name of its type.
+func parseProtoToken(b []byte) (t Token, name string, err error) {
+	err = parseProto(b, func(field int, v uint64, data []byte) error {
+		switch field {
+		case protoTokType:
+			t.Type = TokenType(int64(v))
+		case protoTokName:
+			name = string(data)
+		case protoTokValue:
+			t.Value = string(data)
+		case protoTokIndex:
+			t.Index = int(v)
+		case protoTokSpan:
+			return parseProto(data, func(field int, v uint64, data []byte) error {
+				switch field {
+				case protoSpanLine:
+					t.Line = int(v)
+				case protoSpanCol:
+					t.Col = int(v)
+				case protoSpanOff:
+					t.Offset = int(v)
+				}
+				return nil
+			})
+		}
+		return nil
+	})
+	return t, name, err
+}
+
+// parseProto invokes f for each field of the message b,
+// with its varint value or its length delimited data.
+func parseProto(b []byte, f func(field int, v uint64, data []byte) error) error {
+	for len(b) > 0 {
+		key, n := binary.Uvarint(b)
+		if n <= 0 {
+			return errProtoTruncated
+		}
+		b = b[n:]
+		var (
+			v    uint64
+			data []byte
+		)
+		switch key & 7 {
+		case protoVarint:
+			v, n = binary.Uvarint(b)
+			if n <= 0 {
+				return errProtoTruncated
+			}
+			b = b[n:]
+		case protoFixed64, protoFixed32:
+			size := 8
+			if key&7 == protoFixed32 {
+				size = 4
+			}
+			if len(b) < size {
+				return errProtoTruncated
+			}
+			b = b[size:]
+			continue
+		case protoBytes:
+			size, n := binary.Uvarint(b)
+			if n <= 0 || uint64(len(b)-n) < size {
+				return errProtoTruncated
+			}
+			data = b[n : n+int(size)]
+			b = b[n+int(size):]
+		default:
+			return fmt.Errorf("unsupported protobuf wire type %v", key&7)
+		}
+		if err := f(int(key>>3), v, data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
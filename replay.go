@@ -0,0 +1,132 @@
+package lexer
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"unicode/utf8"
+)
+
+// RecordEvent is an entry of a recording, one of its fields is set.
+type RecordEvent struct {
+	Input string      `json:",omitempty"` // consumed input
+	State string      `json:",omitempty"` // executed state
+	Token *plainToken `json:",omitempty"` // emitted token
+}
+
+// Recorder writes the consumed input, the executed states and the emitted
+// tokens of a lexer, as json lines of RecordEvent.
+type Recorder struct {
+	w     *bufio.Writer
+	enc   *json.Encoder
+	input []byte
+	Err   error
+}
+
+// Record starts recording l into w, the recording can be replayed with Replay.
+// Flush must be called once the lexing ended.
+func (l *L) Record(w io.Writer) *Recorder {
+	bw := bufio.NewWriter(w)
+	l.recorder = &Recorder{w: bw, enc: json.NewEncoder(bw)}
+	return l.recorder
+}
+
+// Flush writes the pending events to the underlying io.Writer.
+func (r *Recorder) Flush() error {
+	r.flushInput()
+	if err := r.w.Flush(); err != nil && r.Err == nil {
+		r.Err = err
+	}
+	return r.Err
+}
+
+func (r *Recorder) consume(c rune) {
+	r.input = utf8.AppendRune(r.input, c)
+}
+
+func (r *Recorder) state(name string) {
+	r.write(RecordEvent{State: name})
+}
+
+func (r *Recorder) token(t Token) {
+	r.write(RecordEvent{Token: (*plainToken)(&t)})
+}
+
+func (r *Recorder) flushInput() {
+	if len(r.input) > 0 {
+		input := string(r.input)
+		r.input = r.input[:0]
+		r.write(RecordEvent{Input: input})
+	}
+}
+
+func (r *Recorder) write(e RecordEvent) {
+	if e.Input == "" {
+		r.flushInput()
+	}
+	if r.Err == nil {
+		r.Err = r.enc.Encode(e)
+	}
+}
+
+// Replay creates a lexer over the input recorded in rec, ready to parse it
+// with start. The lexer reports an error when its states or tokens
+// diverge from the recording, or when it stops before the end of the
+// recording. The errors are collected into Err and Diagnostics,
+// its ErrorHandler can be replaced to receive them.
+func Replay(rec io.Reader, start StateFunc) (*L, error) {
+	var (
+		input  []byte
+		events []RecordEvent
+	)
+	dec := json.NewDecoder(rec)
+	for {
+		var e RecordEvent
+		if err := dec.Decode(&e); err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+		if e.Input != "" {
+			input = append(input, e.Input...)
+		} else {
+			events = append(events, e)
+		}
+	}
+	l := NewBytes(input, start)
+	l.ErrorHandler = func(string) {}
+	l.replay = events
+	return l, nil
+}
+
+// replayed checks e against the next event of the replayed recording.
+func (l *L) replayed(e RecordEvent) {
+	if len(l.replay) == 0 {
+		l.replay = nil
+		l.Error(fmt.Sprintf("replay diverged: unexpected %v", e.String()))
+		return
+	}
+	want := l.replay[0]
+	l.replay = l.replay[1:]
+	if want.String() != e.String() {
+		l.replay = nil
+		l.Error(fmt.Sprintf("replay diverged: expected %v, got %v", want.String(), e.String()))
+	}
+}
+
+// replayMissing reports the recorded events left once the lexing stopped.
+func (l *L) replayMissing() {
+	want := l.replay[0]
+	n := len(l.replay)
+	l.replay = nil
+	l.Error(fmt.Sprintf("replay diverged: stopped before %v, %v events missing", want.String(), n))
+}
+
+func (e RecordEvent) String() string {
+	if e.Token != nil {
+		b, _ := Token(*e.Token).MarshalText()
+		return "token " + string(b)
+	}
+	return "state " + e.State
+}
@@ -0,0 +1,70 @@
+package lexer
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func Test_RecordReplay(t *testing.T) {
+	var rec bytes.Buffer
+	l := New(bytes.NewBufferString("123.hello  675.world"), numberState)
+	r := l.Record(&rec)
+	expected, _ := l.All()
+	if err := r.Flush(); err != nil {
+		t.Errorf("Unexpected error %v", err)
+		return
+	}
+
+	l, err := Replay(bytes.NewReader(rec.Bytes()), numberState)
+	if err != nil {
+		t.Errorf("Unexpected error %v", err)
+		return
+	}
+	tokens, err := l.All()
+	if err != nil {
+		t.Errorf("Unexpected error %v", err)
+		return
+	}
+	if len(tokens) != len(expected) {
+		t.Errorf("Expected %v tokens but got %v", len(expected), len(tokens))
+		return
+	}
+
+	l, _ = Replay(bytes.NewReader(rec.Bytes()), identState)
+	var errs []string
+	l.ErrorHandler = func(e string) {
+		errs = append(errs, e)
+	}
+	l.All()
+	if len(errs) == 0 || !strings.HasPrefix(errs[0], "replay diverged") {
+		t.Errorf("Expected a replay error, but got %v", errs)
+		return
+	}
+}
+
+func Test_ReplayStoppedEarly(t *testing.T) {
+	stop := false
+	var state StateFunc
+	state = func(l *L) StateFunc {
+		l.Take("0123456789.")
+		if l.Current() == "" || stop {
+			return nil
+		}
+		l.Emit(NumberToken)
+		return state
+	}
+	var rec bytes.Buffer
+	l := New(bytes.NewBufferString("1.2"), state)
+	r := l.Record(&rec)
+	l.All()
+	r.Flush()
+
+	stop = true
+	l, _ = Replay(bytes.NewReader(rec.Bytes()), state)
+	_, err := l.All()
+	if err == nil || !strings.Contains(err.Error(), "replay diverged: stopped before") {
+		t.Errorf("Expected a replay error, but got %v", err)
+		return
+	}
+}
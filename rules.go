@@ -0,0 +1,187 @@
+package lexer
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"unicode/utf8"
+)
+
+// Rule defines a token by a regular expression of the regexp package syntax.
+// Patterns support the Unicode classes such as \p{L} or \p{Nd},
+// and the ASCII classes such as [[:alpha:]].
+type Rule struct {
+	Type    TokenType
+	Pattern string
+	Skip    bool     // ignore the matched value instead of emitting it
+	Modes   []string // modes the rule is active in, the default mode if empty, "*" for all
+	Action  func(*L) // invoked once the rule matched, such as to change the mode
+	// Fallback rules match the input until another rule matches,
+	// when no other rule matches. Their Pattern is ignored.
+	Fallback bool
+}
+
+// Rules is a table driven lexer, its State function emits the token of
+// the longest matching rule, ties are broken by the rules order.
+//
+//	rules, err := lexer.NewRules(
+//		lexer.Rule{Type: IdentToken, Pattern: `[a-z]+`},
+//		lexer.Rule{Type: NumberToken, Pattern: `[0-9]+`},
+//		lexer.Rule{Pattern: `\s+`, Skip: true},
+//	)
+//	l := lexer.New(src, rules.State)
+type Rules struct {
+	rules []compiledRule
+}
+
+type compiledRule struct {
+	Rule
+	re *regexp.Regexp
+}
+
+// NewRules compiles rules.
+func NewRules(rules ...Rule) (*Rules, error) {
+	r := &Rules{}
+	for _, rule := range rules {
+		if rule.Fallback {
+			r.rules = append(r.rules, compiledRule{Rule: rule})
+			continue
+		}
+		re, err := regexp.Compile(`^(?:` + rule.Pattern + `)`)
+		if err != nil {
+			return nil, fmt.Errorf("invalid rule %q: %v", rule.Pattern, err)
+		}
+		re.Longest()
+		r.rules = append(r.rules, compiledRule{Rule: rule, re: re})
+	}
+	return r, nil
+}
+
+// State emits the token of the longest matching rule.
+// When no rule matches, it emits the fallback rule of the mode,
+// otherwise it reports an error and stops.
+func (r *Rules) State(l *L) StateFunc {
+	if l.Peek() == EOFRune {
+		return nil
+	}
+	mode := l.Mode()
+	best, size := r.longest(l, mode)
+	if best < 0 {
+		best = r.fallback(mode)
+		if best < 0 {
+			l.Error(fmt.Sprintf("unexpected token %q", l.Peek()))
+			return nil
+		}
+		for l.Next() != EOFRune {
+			if i, _ := r.longest(l, mode); i > -1 {
+				break
+			}
+		}
+	}
+	for size > 0 {
+		size -= utf8.RuneLen(l.Next())
+	}
+	if r.rules[best].Skip {
+		l.Ignore()
+	} else {
+		l.Emit(r.rules[best].Type)
+	}
+	if r.rules[best].Action != nil {
+		r.rules[best].Action(l)
+	}
+	return r.State
+}
+
+// longest returns the index of the longest matching rule and the size of its match,
+// or -1 if no rule matches.
+func (r *Rules) longest(l *L, mode string) (int, int) {
+	best, size := -1, 0
+	for i, rule := range r.rules {
+		if rule.Fallback || !rule.activeIn(mode) {
+			continue
+		}
+		if n := l.match(rule.re); n > size {
+			best, size = i, n
+		}
+	}
+	return best, size
+}
+
+// fallback returns the index of the fallback rule of mode, or -1.
+func (r *Rules) fallback(mode string) int {
+	for i, rule := range r.rules {
+		if rule.Fallback && rule.activeIn(mode) {
+			return i
+		}
+	}
+	return -1
+}
+
+func (r *compiledRule) activeIn(mode string) bool {
+	if len(r.Modes) == 0 {
+		return mode == ""
+	}
+	for _, m := range r.Modes {
+		if m == mode || m == "*" {
+			return true
+		}
+	}
+	return false
+}
+
+// Mode returns the current mode of the rules, the default mode is "".
+func (l *L) Mode() string {
+	if len(l.modes) == 0 {
+		return ""
+	}
+	return l.modes[len(l.modes)-1]
+}
+
+// PushMode enters mode, until PopMode.
+func (l *L) PushMode(mode string) {
+	l.modes = append(l.modes, mode)
+}
+
+// PopMode returns to the mode preceding the last PushMode.
+func (l *L) PopMode() {
+	if len(l.modes) > 0 {
+		l.modes = l.modes[:len(l.modes)-1]
+	}
+}
+
+// SetMode replaces the current mode with mode.
+func (l *L) SetMode(mode string) {
+	if len(l.modes) == 0 {
+		l.modes = append(l.modes, mode)
+	} else {
+		l.modes[len(l.modes)-1] = mode
+	}
+}
+
+// match returns the size in bytes of the match of re at the current position.
+func (l *L) match(re *regexp.Regexp) int {
+	rr := &runeReader{l: l}
+	loc := re.FindReaderIndex(rr)
+	for ; rr.n > 0; rr.n-- {
+		l.Rewind()
+	}
+	if loc == nil {
+		return 0
+	}
+	return loc[1]
+}
+
+// runeReader reads the lexer input, it counts the runes to rewind.
+type runeReader struct {
+	l *L
+	n int
+}
+
+func (rr *runeReader) ReadRune() (rune, int, error) {
+	r := rr.l.Next()
+	rr.n++
+	if r == EOFRune {
+		return 0, 0, io.EOF
+	}
+	return r, utf8.RuneLen(r), nil
+}
@@ -0,0 +1,138 @@
+package lexer
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+)
+
+func Test_RulesLongestMatch(t *testing.T) {
+	const (
+		IfToken TokenType = iota + 10
+		EqToken
+		AssignToken
+	)
+	rules, err := NewRules(
+		Rule{Type: IfToken, Pattern: `if`},
+		Rule{Type: IdentToken, Pattern: `[a-z]+`},
+		Rule{Type: NumberToken, Pattern: `[0-9]+`},
+		Rule{Type: AssignToken, Pattern: `=`},
+		Rule{Type: EqToken, Pattern: `==`},
+		Rule{Pattern: `\s+`, Skip: true},
+	)
+	if err != nil {
+		t.Errorf("Unexpected error %v", err)
+		return
+	}
+
+	l := New(bytes.NewBufferString("if iffy == 12 = é"), rules.State)
+	l.ErrorHandler = func(e string) {}
+	tokens, err := l.All()
+	if err == nil || err.Error() != "unexpected token 'é'" {
+		t.Errorf("Expected specific error, but got %v", err)
+		return
+	}
+	expected := []Token{
+		{Type: IfToken, Value: "if", Line: 1, Col: 1, Offset: 0, Index: 0},
+		{Type: IdentToken, Value: "iffy", Line: 1, Col: 4, Offset: 3, Index: 1},
+		{Type: EqToken, Value: "==", Line: 1, Col: 9, Offset: 8, Index: 2},
+		{Type: NumberToken, Value: "12", Line: 1, Col: 12, Offset: 11, Index: 3},
+		{Type: AssignToken, Value: "=", Line: 1, Col: 15, Offset: 14, Index: 4},
+	}
+	if fmt.Sprint(tokens) != fmt.Sprint(expected) {
+		t.Errorf("Expected %v but got %v", expected, tokens)
+		return
+	}
+}
+
+func Test_RulesModes(t *testing.T) {
+	const (
+		QuoteToken TokenType = iota + 10
+		StringToken
+	)
+	rules, err := NewRules(
+		Rule{Type: QuoteToken, Pattern: `"`, Action: func(l *L) { l.PushMode("string") }},
+		Rule{Type: IdentToken, Pattern: `[a-z]+`},
+		Rule{Pattern: `\s+`, Skip: true},
+		Rule{Type: QuoteToken, Pattern: `"`, Modes: []string{"string"}, Action: (*L).PopMode},
+		Rule{Type: StringToken, Pattern: `[^"]+`, Modes: []string{"string"}},
+	)
+	if err != nil {
+		t.Errorf("Unexpected error %v", err)
+		return
+	}
+
+	tokens, err := New(bytes.NewBufferString(`ab "c d" e`), rules.State).All()
+	if err != nil {
+		t.Errorf("Unexpected error %v", err)
+		return
+	}
+	expected := []Token{
+		{Type: IdentToken, Value: "ab", Line: 1, Col: 1, Offset: 0, Index: 0},
+		{Type: QuoteToken, Value: `"`, Line: 1, Col: 4, Offset: 3, Index: 1},
+		{Type: StringToken, Value: "c d", Line: 1, Col: 5, Offset: 4, Index: 2},
+		{Type: QuoteToken, Value: `"`, Line: 1, Col: 8, Offset: 7, Index: 3},
+		{Type: IdentToken, Value: "e", Line: 1, Col: 10, Offset: 9, Index: 4},
+	}
+	if fmt.Sprint(tokens) != fmt.Sprint(expected) {
+		t.Errorf("Expected %v but got %v", expected, tokens)
+		return
+	}
+}
+
+func Test_RulesFallback(t *testing.T) {
+	const ErrorToken TokenType = 10
+	rules, err := NewRules(
+		Rule{Type: IdentToken, Pattern: `[a-z]+`},
+		Rule{Pattern: `\s+`, Skip: true},
+		Rule{Type: ErrorToken, Fallback: true},
+	)
+	if err != nil {
+		t.Errorf("Unexpected error %v", err)
+		return
+	}
+
+	tokens, err := New(bytes.NewBufferString(`ab 12c d!`), rules.State).All()
+	if err != nil {
+		t.Errorf("Unexpected error %v", err)
+		return
+	}
+	expected := []Token{
+		{Type: IdentToken, Value: "ab", Line: 1, Col: 1, Offset: 0, Index: 0},
+		{Type: ErrorToken, Value: "12", Line: 1, Col: 4, Offset: 3, Index: 1},
+		{Type: IdentToken, Value: "c", Line: 1, Col: 6, Offset: 5, Index: 2},
+		{Type: IdentToken, Value: "d", Line: 1, Col: 8, Offset: 7, Index: 3},
+		{Type: ErrorToken, Value: "!", Line: 1, Col: 9, Offset: 8, Index: 4},
+	}
+	if fmt.Sprint(tokens) != fmt.Sprint(expected) {
+		t.Errorf("Expected %v but got %v", expected, tokens)
+		return
+	}
+}
+
+func Test_RulesUnicodeClasses(t *testing.T) {
+	rules, err := NewRules(
+		Rule{Type: IdentToken, Pattern: `[\p{L}_][\p{L}\p{Nd}_]*`},
+		Rule{Type: NumberToken, Pattern: `[[:digit:]]+`},
+		Rule{Pattern: `\s+`, Skip: true},
+	)
+	if err != nil {
+		t.Errorf("Unexpected error %v", err)
+		return
+	}
+
+	tokens, err := New(bytes.NewBufferString(`été_١٢ 42 Straße`), rules.State).All()
+	if err != nil {
+		t.Errorf("Unexpected error %v", err)
+		return
+	}
+	expected := []Token{
+		{Type: IdentToken, Value: "été_١٢", Line: 1, Col: 1, Offset: 0, Index: 0},
+		{Type: NumberToken, Value: "42", Line: 1, Col: 8, Offset: 11, Index: 1},
+		{Type: IdentToken, Value: "Straße", Line: 1, Col: 11, Offset: 14, Index: 2},
+	}
+	if fmt.Sprint(tokens) != fmt.Sprint(expected) {
+		t.Errorf("Expected %v but got %v", expected, tokens)
+		return
+	}
+}
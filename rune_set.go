@@ -0,0 +1,132 @@
+package lexer
+
+import (
+	"sort"
+	"unicode"
+	"unicode/utf8"
+)
+
+// RuneSet is a set of runes with a fast membership test,
+// a bitmap for ASCII and sorted ranges for the other runes.
+// The zero RuneSet is empty.
+//
+//	ident := lexer.NewRuneSet("_").AddTable(unicode.Letter).AddRange('0', '9')
+type RuneSet struct {
+	ascii  asciiSet
+	ranges []runeRange // sorted and disjoint, above ASCII
+}
+
+type runeRange struct {
+	lo, hi rune
+}
+
+// NewRuneSet returns the set of chars.
+func NewRuneSet(chars string) *RuneSet {
+	return new(RuneSet).AddString(chars)
+}
+
+// AddString adds chars to s and returns it.
+func (s *RuneSet) AddString(chars string) *RuneSet {
+	for _, r := range chars {
+		s.add(r, r)
+	}
+	s.normalize()
+	return s
+}
+
+// AddRange adds the runes from lo to hi, included, to s and returns it.
+func (s *RuneSet) AddRange(lo, hi rune) *RuneSet {
+	s.add(lo, hi)
+	s.normalize()
+	return s
+}
+
+// AddTable adds the runes of t, such as unicode.Letter, to s and returns it.
+func (s *RuneSet) AddTable(t *unicode.RangeTable) *RuneSet {
+	for _, r := range t.R16 {
+		s.addStride(rune(r.Lo), rune(r.Hi), rune(r.Stride))
+	}
+	for _, r := range t.R32 {
+		s.addStride(rune(r.Lo), rune(r.Hi), rune(r.Stride))
+	}
+	s.normalize()
+	return s
+}
+
+// Contains reports whether r is in s.
+func (s *RuneSet) Contains(r rune) bool {
+	if r < utf8.RuneSelf {
+		return s.ascii.contains(r)
+	}
+	i := sort.Search(len(s.ranges), func(i int) bool {
+		return s.ranges[i].hi >= r
+	})
+	return i < len(s.ranges) && s.ranges[i].lo <= r
+}
+
+func (s *RuneSet) addStride(lo, hi, stride rune) {
+	if stride == 1 {
+		s.add(lo, hi)
+		return
+	}
+	for r := lo; r <= hi; r += stride {
+		s.add(r, r)
+	}
+}
+
+// add adds the runes from lo to hi, s must then be normalized.
+func (s *RuneSet) add(lo, hi rune) {
+	for ; lo <= hi && lo < utf8.RuneSelf; lo++ {
+		if lo >= 0 {
+			s.ascii[lo/64] |= 1 << (uint(lo) % 64)
+		}
+	}
+	if lo <= hi {
+		s.ranges = append(s.ranges, runeRange{lo, hi})
+	}
+}
+
+// normalize sorts and merges the ranges of s.
+func (s *RuneSet) normalize() {
+	sort.Slice(s.ranges, func(i, j int) bool {
+		return s.ranges[i].lo < s.ranges[j].lo
+	})
+	merged := s.ranges[:0]
+	for _, r := range s.ranges {
+		if n := len(merged); n > 0 && r.lo <= merged[n-1].hi+1 {
+			if r.hi > merged[n-1].hi {
+				merged[n-1].hi = r.hi
+			}
+			continue
+		}
+		merged = append(merged, r)
+	}
+	s.ranges = merged
+}
+
+// TakeSet consumes the runes of s, see Take.
+func (l *L) TakeSet(s *RuneSet) {
+	r := l.Next()
+	for r != EOFRune && s.Contains(r) {
+		r = l.Next()
+	}
+	l.Rewind() // last next wasn't a match
+}
+
+// AcceptSet consumes the next rune if it is in s, and reports whether it did.
+func (l *L) AcceptSet(s *RuneSet) bool {
+	if r := l.Next(); r != EOFRune && s.Contains(r) {
+		return true
+	}
+	l.Rewind()
+	return false
+}
+
+// TakeUntilSet consumes the source until a rune of s, or until EOF.
+func (l *L) TakeUntilSet(s *RuneSet) {
+	r := l.Next()
+	for r != EOFRune && !s.Contains(r) {
+		r = l.Next()
+	}
+	l.Rewind() // last next wasn't a match
+}
@@ -0,0 +1,48 @@
+package lexer
+
+import (
+	"testing"
+	"unicode"
+)
+
+func Test_RuneSet(t *testing.T) {
+	s := NewRuneSet("_$").AddTable(unicode.Greek).AddRange('0', '9').AddRange('a', 'f').AddRange('é', 'é')
+	for r, expected := range map[rune]bool{
+		'_': true, '$': true, '5': true, 'c': true, 'g': false, 'é': true, 'è': false,
+		'λ': true, 'Ω': true, 'ж': false, '\U0001D6C2': false, -1: false,
+	} {
+		if s.Contains(r) != expected {
+			t.Errorf("Expected %v but got %v for %q", expected, s.Contains(r), r)
+			return
+		}
+	}
+	var zero RuneSet
+	if zero.Contains('a') || zero.Contains('λ') {
+		t.Errorf("Expected the zero RuneSet to be empty")
+		return
+	}
+}
+
+func Test_TakeSet(t *testing.T) {
+	digits := NewRuneSet("0123456789")
+	l := NewString("12ab; x", nil)
+	l.TakeSet(digits)
+	if l.Current() != "12" {
+		t.Errorf("Expected %q but got %q", "12", l.Current())
+		return
+	}
+	if l.AcceptSet(digits) {
+		t.Errorf("Expected %v but got %v", false, true)
+		return
+	}
+	l.TakeUntilSet(NewRuneSet(";"))
+	if l.Current() != "12ab" {
+		t.Errorf("Expected %q but got %q", "12ab", l.Current())
+		return
+	}
+	l.TakeUntilSet(NewRuneSet("#"))
+	if l.Current() != "12ab; x" {
+		t.Errorf("Expected %q but got %q", "12ab; x", l.Current())
+		return
+	}
+}
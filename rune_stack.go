@@ -1,12 +1,8 @@
 package lexer
 
-type runeNode struct {
-	r    rune
-	next *runeNode
-}
-
+// runeStack is a stack of runes, its storage is reused once cleared.
 type runeStack struct {
-	start *runeNode
+	runes []rune
 }
 
 func newRuneStack() runeStack {
@@ -14,24 +10,26 @@ func newRuneStack() runeStack {
 }
 
 func (s *runeStack) push(r rune) {
-	node := &runeNode{r: r}
-	if s.start == nil {
-		s.start = node
-	} else {
-		node.next = s.start
-		s.start = node
-	}
+	s.runes = append(s.runes, r)
 }
 
 func (s *runeStack) pop() rune {
-	if s.start == nil {
+	if len(s.runes) == 0 {
 		return EOFRune
 	}
-	n := s.start
-	s.start = n.next
-	return n.r
+	r := s.runes[len(s.runes)-1]
+	s.runes = s.runes[:len(s.runes)-1]
+	return r
 }
 
 func (s *runeStack) clear() {
-	s.start = nil
+	if cap(s.runes) > compactSize {
+		s.runes = nil
+	} else {
+		s.runes = s.runes[:0]
+	}
+}
+
+func (s *runeStack) empty() bool {
+	return len(s.runes) == 0
 }
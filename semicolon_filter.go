@@ -0,0 +1,63 @@
+package lexer
+
+import "strings"
+
+// SemicolonFilter inserts terminator tokens at the line ends, Go style:
+// a Semicolon is inserted after the last token of a line if its type
+// ends a statement. Flush must be called at the end of the stream,
+// to terminate the last line.
+type SemicolonFilter struct {
+	// Semicolon is the inserted token, it is located after the token ending the line.
+	Semicolon Token
+	// After are the types of the tokens ending a statement,
+	// such as identifiers, literals, and closing brackets.
+	After map[TokenType]bool
+	// Handler receives the filtered tokens.
+	Handler func(t Token)
+	// Columns is the unit of the columns of the tokens, see L.Columns.
+	Columns ColumnUnit
+
+	prev     *Token
+	inserted int // shifts the Index of the tokens following the inserted ones
+}
+
+// Handle filters t, it is suitable as a TokenHandler or a Scan callback.
+// The tokens are renumbered to account for the inserted ones, see Token.Index.
+func (f *SemicolonFilter) Handle(t Token) {
+	if f.prev != nil && t.Type != f.Semicolon.Type {
+		if line, _ := tokenEnd(*f.prev, f.Columns); t.Line > line {
+			f.terminate()
+		}
+	}
+	t.Index += f.inserted
+	f.prev = &t
+	f.Handler(t)
+}
+
+// Flush terminates the last line.
+func (f *SemicolonFilter) Flush() {
+	if f.prev != nil {
+		f.terminate()
+		f.prev = nil
+	}
+}
+
+func (f *SemicolonFilter) terminate() {
+	if !f.After[f.prev.Type] {
+		return
+	}
+	t := f.Semicolon
+	t.Line, t.Col = tokenEnd(*f.prev, f.Columns)
+	t.Offset = f.prev.Offset + len(f.prev.Value)
+	t.Index = f.prev.Index + 1
+	f.inserted++
+	f.Handler(t)
+}
+
+// tokenEnd returns the position following t, its columns counted in unit.
+func tokenEnd(t Token, unit ColumnUnit) (line, col int) {
+	if i := strings.LastIndexByte(t.Value, '\n'); i > -1 {
+		return t.Line + strings.Count(t.Value, "\n"), 1 + unit.width(t.Value[i+1:])
+	}
+	return t.Line, t.Col + unit.width(t.Value)
+}
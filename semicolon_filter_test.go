@@ -0,0 +1,59 @@
+package lexer
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+)
+
+func Test_SemicolonFilter(t *testing.T) {
+	const SemicolonToken TokenType = 10
+	rules, _ := NewRules(
+		Rule{Type: IdentToken, Pattern: `[a-z]+`},
+		Rule{Type: NumberToken, Pattern: `[0-9]+`},
+		Rule{Type: OpToken, Pattern: `[=+(){]`},
+		Rule{Type: SemicolonToken, Pattern: `;`},
+		Rule{Pattern: `\s+`, Skip: true},
+	)
+	var got []string
+	f := &SemicolonFilter{
+		Semicolon: Token{Type: SemicolonToken, Value: "\n"},
+		After:     map[TokenType]bool{IdentToken: true, NumberToken: true},
+		Handler: func(t Token) {
+			got = append(got, fmt.Sprintf("%q@%v:%v", t.Value, t.Line, t.Col))
+		},
+	}
+	New(bytes.NewBufferString("a = 1 +\n2\nb;\nf {\nc"), rules.State).Scan(f.Handle)
+	f.Flush()
+
+	expected := `["a"@1:1 "="@1:3 "1"@1:5 "+"@1:7 "2"@2:1 "\n"@2:2 "b"@3:1 ";"@3:2 "f"@4:1 "{"@4:3 "c"@5:1 "\n"@5:2]`
+	if fmt.Sprint(got) != expected {
+		t.Errorf("Expected %v but got %v", expected, got)
+		return
+	}
+}
+
+func Test_SemicolonFilterPositions(t *testing.T) {
+	const SemicolonToken TokenType = 10
+	rules, _ := NewRules(
+		Rule{Type: IdentToken, Pattern: `[^\s]+`},
+		Rule{Pattern: `\s+`, Skip: true},
+	)
+	var got []string
+	f := &SemicolonFilter{
+		Semicolon: Token{Type: SemicolonToken, Value: "\n"},
+		After:     map[TokenType]bool{IdentToken: true},
+		Columns:   ColumnUTF16,
+		Handler: func(t Token) {
+			got = append(got, fmt.Sprintf("%+q@%v:%v#%v", t.Value, t.Line, t.Col, t.Index))
+		},
+	}
+	New(bytes.NewBufferString("a\U0001F600\nb"), rules.State, WithColumns(ColumnUTF16)).Scan(f.Handle)
+	f.Flush()
+
+	expected := `["a\U0001f600"@1:1#0 "\n"@1:4#1 "b"@2:1#2 "\n"@2:2#3]`
+	if fmt.Sprint(got) != expected {
+		t.Errorf("Expected %v but got %v", expected, got)
+		return
+	}
+}
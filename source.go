@@ -0,0 +1,289 @@
+package lexer
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"unicode/utf8"
+)
+
+// RuneSource is the input of a lexer.
+type RuneSource interface {
+	// ReadRune reads the next rune and its size in bytes, it returns io.EOF
+	// at the end of the input.
+	ReadRune() (r rune, size int, err error)
+	// Offset returns the number of bytes read so far.
+	Offset() int64
+}
+
+// RuneSeeker is a RuneSource which can move its read offset, see io.Seeker.
+type RuneSeeker interface {
+	RuneSource
+	io.Seeker
+}
+
+// byteIndexer is implemented by the sources searching bytes in their unread input,
+// see L.TakeUntilByte.
+type byteIndexer interface {
+	// indexByte returns the number of bytes before c in the unread input,
+	// or the number of unread bytes if c is not found.
+	indexByte(c byte) int
+}
+
+// slicer is implemented by the sources returning their input as substrings,
+// see L.Current.
+type slicer interface {
+	// slice returns the input from offset from to offset to.
+	slice(from, to int64) string
+}
+
+// waiter is implemented by the sources which may wait for their input,
+// see Follow.
+type waiter interface {
+	// ready reports whether the next rune can be read without waiting.
+	ready() bool
+}
+
+var errInvalidOffset = errors.New("invalid offset")
+
+// seekOffset computes the absolute offset of an io.Seeker within size bytes.
+func seekOffset(current, size, offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekCurrent:
+		offset += current
+	case io.SeekEnd:
+		offset += size
+	}
+	if offset < 0 || offset > size {
+		return current, errInvalidOffset
+	}
+	return offset, nil
+}
+
+// ReaderSource returns a RuneSource reading r, it reads io.RuneReader
+// rune by rune, and other readers byte by byte, without reading ahead but the
+// byte interrupting an invalid sequence. The invalid sequences are decoded as
+// utf8.DecodeRune does, one utf8.RuneError per byte.
+func ReaderSource(r io.Reader) RuneSource {
+	s := &readerSource{r: r}
+	s.rr, _ = r.(io.RuneReader)
+	return s
+}
+
+type readerSource struct {
+	r      io.Reader
+	rr     io.RuneReader
+	p      [utf8.UTFMax]byte
+	b      [1]byte
+	held   []byte // read after an invalid sequence, to decode next
+	offset int64
+}
+
+func (s *readerSource) ReadRune() (rune, int, error) {
+	if s.rr != nil {
+		r, size, err := s.rr.ReadRune()
+		s.offset += int64(size)
+		return r, size, err
+	}
+	c, err := s.readByte()
+	if err != nil {
+		return 0, 0, err
+	}
+	s.p[0] = c
+	size := 1
+	var next []byte
+	if c >= utf8.RuneSelf {
+		for n := runeLen(c); size < n; size++ {
+			c, err := s.readByte()
+			if err != nil {
+				break
+			}
+			if c&0xc0 != 0x80 {
+				next = []byte{c}
+				break
+			}
+			s.p[size] = c
+		}
+	}
+	r, n := utf8.DecodeRune(s.p[:size])
+	if n < size || next != nil {
+		s.held = append(append(append([]byte(nil), s.p[n:size]...), next...), s.held...)
+	}
+	s.offset += int64(n)
+	return r, n, nil
+}
+
+// readByte reads the next byte, held or from the reader.
+func (s *readerSource) readByte() (byte, error) {
+	if len(s.held) > 0 {
+		c := s.held[0]
+		s.held = s.held[1:]
+		return c, nil
+	}
+	if _, err := io.ReadFull(s.r, s.b[:]); err != nil {
+		if err == io.ErrUnexpectedEOF {
+			err = io.EOF
+		}
+		return 0, err
+	}
+	return s.b[0], nil
+}
+
+func (s *readerSource) ready() bool {
+	if w, ok := s.r.(waiter); ok && len(s.held) == 0 {
+		return w.ready()
+	}
+	return true
+}
+
+func (s *readerSource) Offset() int64 {
+	return s.offset
+}
+
+// Remaining returns the input which was not emitted or ignored yet, the
+// current value and the lookahead buffered by l followed by the rest of its source,
+// so another consumer can continue reading it. l must not be used afterwards.
+func (l *L) Remaining() io.Reader {
+	buffered := strings.NewReader(string(l.buf[l.start:]))
+	l.buf, l.start, l.position = l.buf[:0], 0, 0
+	l.rewind.clear()
+	if rs, ok := l.src.(*readerSource); ok && rs.rr == nil {
+		return io.MultiReader(buffered, bytes.NewReader(rs.held), rs.r)
+	}
+	return io.MultiReader(buffered, &sourceReader{src: l.src})
+}
+
+// Drain reads the remaining input of l, see Remaining.
+func (l *L) Drain() (string, error) {
+	b, err := io.ReadAll(l.Remaining())
+	return string(b), err
+}
+
+// sourceReader reads the runes of a RuneSource as UTF-8 bytes.
+type sourceReader struct {
+	src RuneSource
+	p   []byte
+}
+
+func (r *sourceReader) Read(b []byte) (int, error) {
+	for len(r.p) < len(b) {
+		c, size, err := r.src.ReadRune()
+		if err != nil || size == 0 {
+			if len(r.p) > 0 {
+				break
+			}
+			if err == nil {
+				err = io.EOF
+			}
+			return 0, err
+		}
+		r.p = utf8.AppendRune(r.p, c)
+	}
+	n := copy(b, r.p)
+	r.p = r.p[:copy(r.p, r.p[n:])]
+	return n, nil
+}
+
+// runeLen returns the length of the encoding started by b.
+func runeLen(b byte) int {
+	switch {
+	case b&0xE0 == 0xC0:
+		return 2
+	case b&0xF0 == 0xE0:
+		return 3
+	case b&0xF8 == 0xF0:
+		return 4
+	}
+	return 1
+}
+
+// BytesSource returns a RuneSeeker reading b.
+func BytesSource(b []byte) RuneSeeker {
+	return &bytesSource{b: b}
+}
+
+type bytesSource struct {
+	b      []byte
+	offset int
+}
+
+func (s *bytesSource) ReadRune() (rune, int, error) {
+	if s.offset >= len(s.b) {
+		return 0, 0, io.EOF
+	}
+	r, size := utf8.DecodeRune(s.b[s.offset:])
+	s.offset += size
+	return r, size, nil
+}
+
+func (s *bytesSource) Offset() int64 {
+	return int64(s.offset)
+}
+
+func (s *bytesSource) Seek(offset int64, whence int) (int64, error) {
+	offset, err := seekOffset(int64(s.offset), int64(len(s.b)), offset, whence)
+	if err == nil {
+		s.offset = int(offset)
+	}
+	return offset, err
+}
+
+func (s *bytesSource) cursor() RuneSource {
+	c := *s
+	return &c
+}
+
+func (s *bytesSource) indexByte(c byte) int {
+	if i := bytes.IndexByte(s.b[s.offset:], c); i > -1 {
+		return i
+	}
+	return len(s.b) - s.offset
+}
+
+// StringSource returns a RuneSeeker reading s.
+func StringSource(s string) RuneSeeker {
+	return &stringSource{s: s}
+}
+
+type stringSource struct {
+	s      string
+	offset int
+}
+
+func (s *stringSource) ReadRune() (rune, int, error) {
+	if s.offset >= len(s.s) {
+		return 0, 0, io.EOF
+	}
+	r, size := utf8.DecodeRuneInString(s.s[s.offset:])
+	s.offset += size
+	return r, size, nil
+}
+
+func (s *stringSource) Offset() int64 {
+	return int64(s.offset)
+}
+
+func (s *stringSource) Seek(offset int64, whence int) (int64, error) {
+	offset, err := seekOffset(int64(s.offset), int64(len(s.s)), offset, whence)
+	if err == nil {
+		s.offset = int(offset)
+	}
+	return offset, err
+}
+
+func (s *stringSource) slice(from, to int64) string {
+	return s.s[from:to]
+}
+
+func (s *stringSource) cursor() RuneSource {
+	c := *s
+	return &c
+}
+
+func (s *stringSource) indexByte(c byte) int {
+	if i := strings.IndexByte(s.s[s.offset:], c); i > -1 {
+		return i
+	}
+	return len(s.s) - s.offset
+}
@@ -0,0 +1,112 @@
+package lexer
+
+import (
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func Test_ReaderSourceMultiBytes(t *testing.T) {
+	l := New(iotest.OneByteReader(strings.NewReader("hé€😀")), nil)
+	for _, want := range "hé€😀" {
+		if r := l.Next(); r != want {
+			t.Errorf("Expected %q but got %q", want, r)
+			return
+		}
+	}
+	if r := l.Next(); r != EOFRune {
+		t.Errorf("Expected EOF but got %q", r)
+		return
+	}
+	if l.ReadBytes() != len("hé€😀") {
+		t.Errorf("Expected %v bytes read but got %v", len("hé€😀"), l.ReadBytes())
+		return
+	}
+}
+
+func Test_ReaderSourceInvalid(t *testing.T) {
+	for _, src := range []string{"\xc3ab", "\xe2\x82", "\xe2\x82\xe2\x82\xac", "\xc0\x80x", "\xf0\x9f\x98", "a\x80\xbfb"} {
+		l := New(iotest.OneByteReader(strings.NewReader(src)), nil)
+		var got []rune
+		for r := l.Next(); r != EOFRune; r = l.Next() {
+			got = append(got, r)
+		}
+		if string(got) != string([]rune(src)) || l.ReadBytes() != len(src) {
+			t.Errorf("Expected %q but got %q after %v bytes", []rune(src), got, l.ReadBytes())
+			return
+		}
+	}
+	l := New(iotest.OneByteReader(strings.NewReader("\xc3ab")), nil)
+	l.Next()
+	l.Ignore()
+	if s, err := l.Drain(); s != "ab" || err != nil {
+		t.Errorf("Expected %q but got %q %v", "ab", s, err)
+		return
+	}
+}
+
+func Test_StringSourceSeek(t *testing.T) {
+	src := StringSource("héllo")
+	if _, err := src.Seek(3, io.SeekStart); err != nil {
+		t.Errorf("Unexpected error %v", err)
+		return
+	}
+	l := NewSource(src, nil)
+	l.Take("lo")
+	if l.Current() != "llo" || src.Offset() != 6 {
+		t.Errorf("Unexpected value %q at offset %v", l.Current(), src.Offset())
+		return
+	}
+	if _, err := src.Seek(1, io.SeekEnd); err == nil {
+		t.Error("Expected an error, but none found.")
+		return
+	}
+}
+
+func Test_SectionReaderOffsets(t *testing.T) {
+	r := strings.NewReader("€\n12.ab")
+	l := New(io.NewSectionReader(r, 4, 5), numberState)
+	var tokens []Token
+	l.Scan(func(tok Token) {
+		tokens = append(tokens, tok)
+	})
+	want := []int{4, 6, 7}
+	if len(tokens) != len(want) {
+		t.Errorf("Expected %v tokens but got %v", len(want), tokens)
+		return
+	}
+	for i, off := range want {
+		if tokens[i].Offset != off {
+			t.Errorf("Expected offset %v but got %v", off, tokens[i].Offset)
+			return
+		}
+	}
+	if l.ReadBytes() != 9 {
+		t.Errorf("Expected %v bytes read but got %v", 9, l.ReadBytes())
+		return
+	}
+}
+
+func Test_Remaining(t *testing.T) {
+	for _, l := range []*L{
+		NewString("12.ab <p>é</p>", nil),
+		New(strings.NewReader("12.ab <p>é</p>"), nil),
+		New(iotest.OneByteReader(strings.NewReader("12.ab <p>é</p>")), nil),
+	} {
+		l.TakeUntilByte(' ')
+		l.Emit(IdentToken)
+		l.Next()
+		l.Ignore()
+		l.Peek()
+		rest, err := l.Drain()
+		if err != nil {
+			t.Errorf("Unexpected error %v", err)
+			return
+		}
+		if rest != "<p>é</p>" {
+			t.Errorf("Expected %q but got %q", "<p>é</p>", rest)
+			return
+		}
+	}
+}
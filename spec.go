@@ -0,0 +1,166 @@
+package lexer
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// Spec is a rule table lexer loaded at runtime with LoadSpec.
+type Spec struct {
+	Name  string
+	Rules *Rules
+	Types map[string]TokenType // token types by name
+	Names map[TokenType]string // token names by type
+}
+
+// specFile is the json document read by LoadSpec.
+type specFile struct {
+	Name   string `json:"name"`
+	Tokens []struct {
+		Name     string   `json:"name"`
+		Pattern  string   `json:"pattern"`
+		Skip     bool     `json:"skip"`
+		Fallback bool     `json:"fallback"`
+		Modes    []string `json:"modes"`
+		Push     string   `json:"push"`
+		Pop      bool     `json:"pop"`
+		Set      string   `json:"set"`
+	} `json:"tokens"`
+}
+
+// LoadSpec builds a rule table lexer from a json spec such as
+//
+//	{
+//		"name": "words",
+//		"tokens": [
+//			{"name": "word", "pattern": "\\pL+"},
+//			{"name": "quote", "pattern": "\"", "push": "string"},
+//			{"name": "quote", "pattern": "\"", "modes": ["string"], "pop": true},
+//			{"name": "text", "pattern": "[^\"]+", "modes": ["string"]},
+//			{"pattern": "\\s+", "skip": true}
+//		]
+//	}
+//
+// The token types are numbered from 1 in their order of appearance,
+// the rules fields are described by Rule.
+// Skipped rules do not need a name.
+func LoadSpec(r io.Reader) (*Spec, error) {
+	var f specFile
+	if err := json.NewDecoder(r).Decode(&f); err != nil {
+		return nil, fmt.Errorf("invalid spec: %v", err)
+	}
+	spec := &Spec{
+		Name:  f.Name,
+		Types: map[string]TokenType{},
+		Names: map[TokenType]string{},
+	}
+	var rules []Rule
+	for _, t := range f.Tokens {
+		if t.Name == "" && !t.Skip {
+			return nil, fmt.Errorf("invalid spec: rule %q has no name", t.Pattern)
+		}
+		typ, ok := spec.Types[t.Name]
+		if !ok && t.Name != "" {
+			typ = TokenType(len(spec.Types) + 1)
+			spec.Types[t.Name] = typ
+			spec.Names[typ] = t.Name
+		}
+		rules = append(rules, Rule{
+			Type:     typ,
+			Pattern:  t.Pattern,
+			Skip:     t.Skip,
+			Fallback: t.Fallback,
+			Modes:    t.Modes,
+			Action:   modeAction(t.Push, t.Pop, t.Set),
+		})
+	}
+	var err error
+	spec.Rules, err = NewRules(rules...)
+	if err != nil {
+		return nil, fmt.Errorf("invalid spec: %v", err)
+	}
+	return spec, nil
+}
+
+// New creates a returns a lexer ready to parse src with the spec.
+func (s *Spec) New(src io.Reader) *L {
+	l := New(src, s.Rules.State)
+	l.Name = s.Name
+	return l
+}
+
+func modeAction(push string, pop bool, set string) func(*L) {
+	if push == "" && !pop && set == "" {
+		return nil
+	}
+	return func(l *L) {
+		if pop {
+			l.PopMode()
+		}
+		if set != "" {
+			l.SetMode(set)
+		}
+		if push != "" {
+			l.PushMode(push)
+		}
+	}
+}
+
+// Grammar is a version of a spec of a GrammarSet.
+type Grammar struct {
+	*Spec
+	Version int
+}
+
+// GrammarSet holds named specs which can be replaced while running,
+// the lexers created before a replacement keep lexing with their spec.
+// It is safe for concurrent use.
+type GrammarSet struct {
+	mu       sync.RWMutex
+	grammars map[string]*Grammar
+}
+
+// NewGrammarSet creates an empty GrammarSet.
+func NewGrammarSet() *GrammarSet {
+	return &GrammarSet{grammars: map[string]*Grammar{}}
+}
+
+// Load reads the spec of name from r with LoadSpec, and sets it.
+// On error the current grammar of name is kept.
+func (s *GrammarSet) Load(name string, r io.Reader) (*Grammar, error) {
+	spec, err := LoadSpec(r)
+	if err != nil {
+		return nil, err
+	}
+	return s.Set(name, spec), nil
+}
+
+// Set replaces the grammar of name with spec, and returns its new version.
+func (s *GrammarSet) Set(name string, spec *Spec) *Grammar {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	g := &Grammar{Spec: spec, Version: 1}
+	if prev, ok := s.grammars[name]; ok {
+		g.Version = prev.Version + 1
+	}
+	s.grammars[name] = g
+	return g
+}
+
+// Get returns the current grammar of name, or nil.
+func (s *GrammarSet) Get(name string) *Grammar {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.grammars[name]
+}
+
+// New creates a returns a lexer ready to parse src with the current grammar of name.
+func (s *GrammarSet) New(name string, src io.Reader) (*L, error) {
+	g := s.Get(name)
+	if g == nil {
+		return nil, fmt.Errorf("unknown grammar %q", name)
+	}
+	return g.New(src), nil
+}
@@ -0,0 +1,88 @@
+package lexer
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func Test_LoadSpec(t *testing.T) {
+	spec, err := LoadSpec(strings.NewReader(`{
+		"name": "words",
+		"tokens": [
+			{"name": "word", "pattern": "\\pL+"},
+			{"name": "quote", "pattern": "\"", "push": "string"},
+			{"name": "quote", "pattern": "\"", "modes": ["string"], "pop": true},
+			{"name": "text", "pattern": "[^\"]+", "modes": ["string"]},
+			{"pattern": "\\s+", "skip": true}
+		]
+	}`))
+	if err != nil {
+		t.Errorf("Unexpected error %v", err)
+		return
+	}
+
+	tokens, err := spec.New(bytes.NewBufferString(`ab "c d"`)).All()
+	if err != nil {
+		t.Errorf("Unexpected error %v", err)
+		return
+	}
+	var got []string
+	for _, tok := range tokens {
+		got = append(got, spec.Names[tok.Type]+":"+tok.Value)
+	}
+	expected := []string{"word:ab", `quote:"`, "text:c d", `quote:"`}
+	if fmt.Sprint(got) != fmt.Sprint(expected) {
+		t.Errorf("Expected %v but got %v", expected, got)
+		return
+	}
+
+	if _, err := LoadSpec(strings.NewReader(`{"tokens": [{"name": "a", "pattern": "("}]}`)); err == nil {
+		t.Error("Expected an error, but none found.")
+		return
+	}
+}
+
+func Test_GrammarSet(t *testing.T) {
+	set := NewGrammarSet()
+	g, err := set.Load("w", strings.NewReader(`{"tokens": [{"name": "word", "pattern": "[a-z]+"}]}`))
+	if err != nil {
+		t.Errorf("Unexpected error %v", err)
+		return
+	}
+	if g.Version != 1 {
+		t.Errorf("Expected version %v but got %v", 1, g.Version)
+		return
+	}
+	old, _ := set.New("w", bytes.NewBufferString("ab cd"))
+	old.ErrorHandler = func(string) {}
+
+	g, err = set.Load("w", strings.NewReader(`{"tokens": [{"name": "word", "pattern": "[a-z]+"}, {"pattern": " ", "skip": true}]}`))
+	if err != nil {
+		t.Errorf("Unexpected error %v", err)
+		return
+	}
+	if g.Version != 2 {
+		t.Errorf("Expected version %v but got %v", 2, g.Version)
+		return
+	}
+	if _, err := set.Load("w", strings.NewReader(`{`)); err == nil || set.Get("w") != g {
+		t.Error("Expected an error and the grammar to be kept")
+		return
+	}
+
+	if _, err := old.All(); err == nil {
+		t.Error("Expected the old grammar to fail on spaces")
+		return
+	}
+	l, _ := set.New("w", bytes.NewBufferString("ab cd"))
+	if tokens, err := l.All(); err != nil || len(tokens) != 2 {
+		t.Errorf("Expected 2 tokens, got %v %v", tokens, err)
+		return
+	}
+	if _, err := set.New("nope", nil); err == nil {
+		t.Error("Expected an error, but none found.")
+		return
+	}
+}
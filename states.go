@@ -0,0 +1,66 @@
+package lexer
+
+import (
+	"fmt"
+	"unicode"
+)
+
+// WhitespaceState returns a state ignoring the whitespaces, as defined by
+// unicode.IsSpace, then continuing with next. It ends the lexing at EOF.
+func WhitespaceState(next StateFunc) StateFunc {
+	return func(l *L) StateFunc {
+		for unicode.IsSpace(l.Peek()) {
+			l.Next()
+		}
+		l.Ignore()
+		if l.Peek() == EOFRune {
+			return nil
+		}
+		return next
+	}
+}
+
+// NumberState returns a state emitting a decimal or hexadecimal number
+// as a token of type t, then continuing with next, see ScanFloat.
+// A missing or malformed number is an error.
+func NumberState(t TokenType, next StateFunc) StateFunc {
+	return func(l *L) StateFunc {
+		if _, ok := ScanFloat(l); !ok {
+			if l.Current() == "" {
+				l.Error(fmt.Sprintf("unexpected %q, expected a number", l.Peek()))
+			}
+			return nil
+		}
+		l.Emit(t)
+		return next
+	}
+}
+
+// QuotedStringState returns a state emitting a string delimited by quote,
+// quotes included, as a token of type t, then continuing with next.
+// A backslash escapes the rune following it. A missing or unterminated
+// string is an error.
+func QuotedStringState(quote rune, t TokenType, next StateFunc) StateFunc {
+	return func(l *L) StateFunc {
+		if r := l.Peek(); r != quote {
+			l.Error(fmt.Sprintf("unexpected %q, expected %q", r, quote))
+			return nil
+		}
+		l.Next()
+		for {
+			switch l.Next() {
+			case quote:
+				l.Emit(t)
+				return next
+			case '\\':
+				if l.Next() != EOFRune {
+					continue
+				}
+				fallthrough
+			case EOFRune:
+				l.Error("unterminated quoted string")
+				return nil
+			}
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package lexer
+
+import (
+	"fmt"
+	"testing"
+)
+
+func Test_StockStates(t *testing.T) {
+	var value StateFunc
+	value = WhitespaceState(func(l *L) StateFunc {
+		if l.Peek() == '"' {
+			return QuotedStringState('"', IdentToken, value)
+		}
+		return NumberState(NumberToken, value)
+	})
+	l := NewString(` 12 "a\"b" 0x1F
+ 3.5`, value)
+	var got []string
+	err := l.Scan(func(tok Token) {
+		got = append(got, fmt.Sprintf("%v:%v", tok.Type, tok.Value))
+	})
+	expected := `[0:12 2:"a\"b" 0:0x1F 0:3.5]`
+	if err != nil || fmt.Sprint(got) != expected {
+		t.Errorf("Expected %v but got %v %v", expected, got, err)
+		return
+	}
+}
+
+func Test_StockStatesErrors(t *testing.T) {
+	for _, c := range []struct {
+		src      string
+		start    StateFunc
+		expected string
+	}{
+		{"x", NumberState(NumberToken, nil), `unexpected 'x', expected a number`},
+		{"1e", NumberState(NumberToken, nil), `exponent of "1e" has no digits`},
+		{"x", QuotedStringState('\'', IdentToken, nil), `unexpected 'x', expected '\''`},
+		{`'a\'`, QuotedStringState('\'', IdentToken, nil), "unterminated quoted string"},
+	} {
+		l := NewString(c.src, c.start)
+		l.ErrorHandler = func(string) {}
+		l.Scan(func(Token) {})
+		if fmt.Sprint(l.Err) != c.expected {
+			t.Errorf("Expected %v but got %v", c.expected, l.Err)
+			return
+		}
+	}
+}
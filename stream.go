@@ -0,0 +1,38 @@
+package lexer
+
+import "context"
+
+// Stream lexes in a new goroutine, it delivers the tokens on the first channel
+// and the reported errors on the second one, both are closed once the lexing ends
+// or ctx is done. The consumer must receive from both channels,
+// with a select, until they are closed.
+//
+// l must not be used by other goroutines once Stream was invoked,
+// its ErrorHandler is replaced.
+func (l *L) Stream(ctx context.Context) (<-chan Token, <-chan error) {
+	tokens := make(chan Token)
+	errs := make(chan error)
+	l.ErrorHandler = func(string) {}
+	l.OnError(func(e *LexError) {
+		if e.Severity != SeverityError {
+			return
+		}
+		select {
+		case errs <- e:
+		case <-ctx.Done():
+		}
+	})
+	go func() {
+		defer close(tokens)
+		defer close(errs)
+		l.ScanContext(ctx, func(t Token) error {
+			select {
+			case tokens <- t:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		})
+	}()
+	return tokens, errs
+}
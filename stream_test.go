@@ -0,0 +1,58 @@
+package lexer
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"testing"
+)
+
+func Test_Stream(t *testing.T) {
+	rules, _ := NewRules(
+		Rule{Type: IdentToken, Pattern: `[a-z]+`},
+		Rule{Type: 10, Fallback: true, Action: func(l *L) {
+			l.Error("invalid input")
+		}},
+	)
+	l := New(bytes.NewBufferString("a1b2"), rules.State)
+	tokens, errs := l.Stream(context.Background())
+
+	var values []string
+	var got []error
+	for tokens != nil || errs != nil {
+		select {
+		case tok, ok := <-tokens:
+			if !ok {
+				tokens = nil
+				continue
+			}
+			values = append(values, tok.Value)
+		case err, ok := <-errs:
+			if !ok {
+				errs = nil
+				continue
+			}
+			got = append(got, err)
+		}
+	}
+	if fmt.Sprint(values) != "[a 1 b 2]" {
+		t.Errorf("Expected %v but got %v", "[a 1 b 2]", values)
+		return
+	}
+	if fmt.Sprint(got) != "[invalid input invalid input]" {
+		t.Errorf("Expected %v but got %v", "[invalid input invalid input]", got)
+		return
+	}
+}
+
+func Test_StreamCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	l := New(bytes.NewBufferString("1.a 2.b 3.c"), numberState)
+	tokens, errs := l.Stream(ctx)
+	<-tokens
+	cancel()
+	for range tokens {
+	}
+	for range errs {
+	}
+}
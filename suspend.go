@@ -0,0 +1,70 @@
+package lexer
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// checkpoint is the lexer state serialized by Suspend.
+type checkpoint struct {
+	Name   string   `json:"name"`
+	Offset int      `json:"offset"` // of the current token
+	Index  int      `json:"index"`  // of the next token
+	Line   int      `json:"line"`
+	Col    int      `json:"col"`
+	Modes  []string `json:"modes,omitempty"`
+	Done   bool     `json:"done,omitempty"`
+}
+
+// Suspend serializes the position and the modes of l, so the lexing can be
+// continued later with Resume. The value being analyzed is not saved,
+// it is read again from the source when resuming.
+// It should be called between two tokens, such as after NextToken.
+func (l *L) Suspend() ([]byte, error) {
+	if l.next < len(l.pending) {
+		return nil, errors.New("can not suspend the lexer with pending tokens")
+	}
+	return json.Marshal(checkpoint{
+		Name:   l.Name,
+		Offset: l.offset,
+		Index:  l.index,
+		Line:   l.line,
+		Col:    l.col,
+		Modes:  l.modes,
+		Done:   l.startState == nil || l.hasNext && l.nextState == nil,
+	})
+}
+
+// Resume creates a returns a lexer continuing the lexing suspended in state,
+// with grammar. src must be the input of the suspended lexer, it is seeked to
+// the saved offset if it implements io.Seeker, otherwise it must start at that offset.
+func Resume(state []byte, src io.Reader, grammar *Grammar) (*L, error) {
+	var cp checkpoint
+	if err := json.Unmarshal(state, &cp); err != nil {
+		return nil, fmt.Errorf("invalid lexer state: %v", err)
+	}
+	if cp.Name != grammar.Name {
+		return nil, fmt.Errorf("lexer state of %q can not resume with grammar %q", cp.Name, grammar.Name)
+	}
+	if s, ok := src.(io.Seeker); ok {
+		offset := int64(cp.Offset)
+		if sr, ok := src.(*io.SectionReader); ok {
+			_, base, _ := sr.Outer()
+			offset -= base
+		}
+		if _, err := s.Seek(offset, io.SeekStart); err != nil {
+			return nil, err
+		}
+	}
+	l := grammar.New(src)
+	l.SetBaseOffset(cp.Offset)
+	l.line, l.col = cp.Line, cp.Col
+	l.index = cp.Index
+	l.modes = cp.Modes
+	if cp.Done {
+		l.startState = nil
+	}
+	return l, nil
+}
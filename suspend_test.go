@@ -0,0 +1,61 @@
+package lexer
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func Test_SuspendResume(t *testing.T) {
+	spec, err := LoadSpec(strings.NewReader(`{
+		"name": "words",
+		"tokens": [
+			{"name": "word", "pattern": "\\pL+"},
+			{"name": "quote", "pattern": "\"", "push": "string"},
+			{"name": "quote", "pattern": "\"", "modes": ["string"], "pop": true},
+			{"name": "text", "pattern": "[^\"]+", "modes": ["string"]},
+			{"pattern": "\\s+", "skip": true}
+		]
+	}`))
+	if err != nil {
+		t.Errorf("Unexpected error %v", err)
+		return
+	}
+	g := NewGrammarSet().Set("words", spec)
+	src := "é\n\"c d\" ef"
+
+	l := g.New(strings.NewReader(src))
+	l.NextToken()
+	l.NextToken()
+	state, err := l.Suspend()
+	if err != nil {
+		t.Errorf("Unexpected error %v", err)
+		return
+	}
+
+	l, err = Resume(state, strings.NewReader(src), g)
+	if err != nil {
+		t.Errorf("Unexpected error %v", err)
+		return
+	}
+	tokens, err := l.All()
+	if err != nil {
+		t.Errorf("Unexpected error %v", err)
+		return
+	}
+	expected := []Token{
+		{Type: spec.Types["text"], Value: "c d", Line: 2, Col: 2, Offset: 4, Index: 2},
+		{Type: spec.Types["quote"], Value: `"`, Line: 2, Col: 5, Offset: 7, Index: 3},
+		{Type: spec.Types["word"], Value: "ef", Line: 2, Col: 7, Offset: 9, Index: 4},
+	}
+	if fmt.Sprint(tokens) != fmt.Sprint(expected) {
+		t.Errorf("Expected %v but got %v", expected, tokens)
+		return
+	}
+
+	other := NewGrammarSet().Set("other", &Spec{Name: "other", Rules: spec.Rules})
+	if _, err := Resume(state, strings.NewReader(src), other); err == nil {
+		t.Error("Expected an error, but none found.")
+		return
+	}
+}
@@ -0,0 +1,71 @@
+package lexer
+
+import "sync"
+
+// SyncL is a mutex guarded L, it is safe for concurrent use.
+type SyncL struct {
+	mu sync.Mutex
+	l  *L
+}
+
+// Synchronized returns a SyncL guarding l.
+// l must not be used directly afterwards.
+func Synchronized(l *L) *SyncL {
+	return &SyncL{l: l}
+}
+
+// NextToken see L.NextToken.
+func (s *SyncL) NextToken() *Token {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.l.NextToken()
+}
+
+// BatchNext see L.BatchNext.
+func (s *SyncL) BatchNext(dst []Token) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.l.BatchNext(dst)
+}
+
+// ReadToken see L.ReadToken.
+func (s *SyncL) ReadToken() (Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.l.ReadToken()
+}
+
+// NextTokens see L.NextTokens.
+func (s *SyncL) NextTokens() []*Token {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]*Token(nil), s.l.NextTokens()...)
+}
+
+// Scan see L.Scan, the lock is held until the scan ends.
+func (s *SyncL) Scan(f func(t Token)) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.l.Scan(f)
+}
+
+// ReadBytes see L.ReadBytes.
+func (s *SyncL) ReadBytes() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.l.ReadBytes()
+}
+
+// Err returns the last error of the lexer.
+func (s *SyncL) Err() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.l.Err
+}
+
+// Do invokes f with the lock held.
+func (s *SyncL) Do(f func(l *L)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	f(s.l)
+}
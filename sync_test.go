@@ -0,0 +1,36 @@
+package lexer
+
+import (
+	"bytes"
+	"sync"
+	"testing"
+)
+
+func Test_Synchronized(t *testing.T) {
+	b := bytes.NewBufferString("1.a 2.b 3.c 4.d")
+	l := Synchronized(New(b, numberState))
+
+	var (
+		wg    sync.WaitGroup
+		mu    sync.Mutex
+		count int
+	)
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for tok := l.NextToken(); tok != nil; tok = l.NextToken() {
+				l.ReadBytes()
+				mu.Lock()
+				count++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if count != 12 {
+		t.Errorf("Expected %v tokens but got %v", 12, count)
+		return
+	}
+}
@@ -0,0 +1,303 @@
+package lexer
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"encoding/csv"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+)
+
+// Format defines the encoding of a serialized token stream.
+type Format int
+
+const (
+	// JSONL encodes one json object per line, with all the fields of Token.
+	JSONL Format = iota
+	// Binary encodes each token as a varint type, the uvarint line, col, offset
+	// and index, followed by the uvarint length of the value and the value bytes.
+	// The State and the File are not kept.
+	Binary
+	// CSV encodes one "type,value,line,col,offset,index" record per line.
+	// The State and the File are not kept.
+	CSV
+	// Protobuf encodes each token as a Token message of token.proto,
+	// prefixed with its uvarint length. The State and the File are not kept.
+	Protobuf
+)
+
+// plainToken drops the Token methods so encoders use its fields
+// rather than Token.MarshalText.
+type plainToken Token
+
+// init registers Token for encoding/gob, so tokens can be sent as
+// interface values, such as the arguments of net/rpc.
+func init() {
+	gob.Register(Token{})
+}
+
+// TokenWriter encodes tokens to an io.Writer as they are emitted.
+type TokenWriter struct {
+	format Format
+	w      *bufio.Writer
+	csv    *csv.Writer
+	Err    error
+	// Names provides the symbolic names of the token types,
+	// it is used by the Protobuf format.
+	Names map[TokenType]string
+}
+
+// NewTokenWriter creates a TokenWriter encoding tokens with format into w.
+func NewTokenWriter(w io.Writer, format Format) *TokenWriter {
+	tw := &TokenWriter{
+		format: format,
+		w:      bufio.NewWriter(w),
+	}
+	if format == CSV {
+		tw.csv = csv.NewWriter(tw.w)
+	}
+	return tw
+}
+
+// Write encodes t.
+func (w *TokenWriter) Write(t Token) error {
+	if w.Err != nil {
+		return w.Err
+	}
+	switch w.format {
+	case JSONL:
+		b, err := json.Marshal(plainToken(t))
+		if err == nil {
+			b = append(b, '\n')
+			_, err = w.w.Write(b)
+		}
+		w.Err = err
+	case Binary:
+		buf := make([]byte, 6*binary.MaxVarintLen64, 6*binary.MaxVarintLen64+len(t.Value))
+		n := binary.PutVarint(buf, int64(t.Type))
+		n += binary.PutUvarint(buf[n:], uint64(t.Line))
+		n += binary.PutUvarint(buf[n:], uint64(t.Col))
+		n += binary.PutUvarint(buf[n:], uint64(t.Offset))
+		n += binary.PutUvarint(buf[n:], uint64(t.Index))
+		n += binary.PutUvarint(buf[n:], uint64(len(t.Value)))
+		buf = append(buf[:n], t.Value...)
+		_, w.Err = w.w.Write(buf)
+	case CSV:
+		w.Err = w.csv.Write([]string{
+			strconv.Itoa(int(t.Type)),
+			t.Value,
+			strconv.Itoa(t.Line),
+			strconv.Itoa(t.Col),
+			strconv.Itoa(t.Offset),
+			strconv.Itoa(t.Index),
+		})
+	case Protobuf:
+		msg := appendProtoToken(nil, t, w.Names[t.Type])
+		buf := binary.AppendUvarint(make([]byte, 0, binary.MaxVarintLen64+len(msg)), uint64(len(msg)))
+		_, w.Err = w.w.Write(append(buf, msg...))
+	default:
+		w.Err = fmt.Errorf("unknown token stream format %v", w.format)
+	}
+	return w.Err
+}
+
+// Handle encodes t, it is suitable as a TokenHandler or a Scan callback.
+// Errors are recorded into w.Err.
+func (w *TokenWriter) Handle(t Token) {
+	w.Write(t)
+}
+
+// Flush writes any buffered data to the underlying io.Writer.
+func (w *TokenWriter) Flush() error {
+	if w.csv != nil {
+		w.csv.Flush()
+		if err := w.csv.Error(); err != nil && w.Err == nil {
+			w.Err = err
+		}
+	}
+	if err := w.w.Flush(); err != nil && w.Err == nil {
+		w.Err = err
+	}
+	return w.Err
+}
+
+// TokenReader decodes tokens previously encoded by a TokenWriter.
+type TokenReader struct {
+	format Format
+	r      *bufio.Reader
+	csv    *csv.Reader
+	Err    error
+	// Names collects the symbolic names of the token types read,
+	// they are provided by the Protobuf format, see TokenWriter.Names.
+	Names map[TokenType]string
+}
+
+// NewTokenReader creates a TokenReader decoding tokens with format from r.
+func NewTokenReader(r io.Reader, format Format) *TokenReader {
+	tr := &TokenReader{
+		format: format,
+		r:      bufio.NewReader(r),
+	}
+	if format == CSV {
+		tr.csv = csv.NewReader(tr.r)
+		tr.csv.FieldsPerRecord = 6
+	}
+	return tr
+}
+
+// NextToken Reads the next token, it returns nil at EOF or on error.
+func (r *TokenReader) NextToken() *Token {
+	if r.Err != nil {
+		return nil
+	}
+	t, err := r.read()
+	if err != nil {
+		if err != io.EOF {
+			r.Err = err
+		}
+		return nil
+	}
+	return &t
+}
+
+// ReadToken reads the next token, it returns io.EOF at the end of the stream,
+// or the decoding error, see L.ReadToken.
+func (r *TokenReader) ReadToken() (Token, error) {
+	if t := r.NextToken(); t != nil {
+		return *t, nil
+	}
+	if r.Err != nil {
+		return Token{}, r.Err
+	}
+	return Token{}, io.EOF
+}
+
+// Scan Browses all tokens and invokes f for each of them.
+// It returns the decoding error, if any.
+func (r *TokenReader) Scan(f func(t Token)) error {
+	for t := r.NextToken(); t != nil; t = r.NextToken() {
+		f(*t)
+	}
+	return r.Err
+}
+
+func (r *TokenReader) read() (Token, error) {
+	var t Token
+	switch r.format {
+	case JSONL:
+		line, err := r.r.ReadBytes('\n')
+		if err == io.EOF && len(line) > 0 {
+			err = io.ErrUnexpectedEOF
+		}
+		if err != nil {
+			return t, err
+		}
+		err = json.Unmarshal(line, (*plainToken)(&t))
+		return t, err
+	case Binary:
+		typ, err := binary.ReadVarint(r.r)
+		if err != nil {
+			return t, err
+		}
+		var pos [5]uint64
+		for i := range pos {
+			pos[i], err = binary.ReadUvarint(r.r)
+			if err != nil {
+				return t, unexpectedEOF(err)
+			}
+		}
+		line, col, offset, index, size := pos[0], pos[1], pos[2], pos[3], pos[4]
+		value, err := readSized(r.r, size)
+		if err != nil {
+			return t, err
+		}
+		t.Type, t.Value = TokenType(typ), string(value)
+		t.Line, t.Col, t.Offset = int(line), int(col), int(offset)
+		t.Index = int(index)
+		return t, nil
+	case CSV:
+		record, err := r.csv.Read()
+		if err != nil {
+			return t, err
+		}
+		var fields [5]int
+		for i, f := range []string{record[0], record[2], record[3], record[4], record[5]} {
+			fields[i], err = strconv.Atoi(f)
+			if err != nil {
+				return t, err
+			}
+		}
+		t.Type, t.Value = TokenType(fields[0]), record[1]
+		t.Line, t.Col, t.Offset = fields[1], fields[2], fields[3]
+		t.Index = fields[4]
+		return t, nil
+	case Protobuf:
+		size, err := binary.ReadUvarint(r.r)
+		if err != nil {
+			return t, err
+		}
+		msg, err := readSized(r.r, size)
+		if err != nil {
+			return t, err
+		}
+		t, name, err := parseProtoToken(msg)
+		if err == nil && name != "" {
+			if r.Names == nil {
+				r.Names = map[TokenType]string{}
+			}
+			r.Names[t.Type] = name
+		}
+		return t, err
+	}
+	return t, fmt.Errorf("unknown token stream format %v", r.format)
+}
+
+// maxSized bounds the lengths prefixing the values of a token stream.
+const maxSized = math.MaxInt32
+
+// readSized reads the size bytes following a length prefix, the buffer
+// grows with the bytes actually read rather than trusting size.
+func readSized(r io.Reader, size uint64) ([]byte, error) {
+	if size > maxSized {
+		return nil, fmt.Errorf("invalid token stream length %v", size)
+	}
+	var b bytes.Buffer
+	if _, err := io.CopyN(&b, r, int64(size)); err != nil {
+		return nil, unexpectedEOF(err)
+	}
+	return b.Bytes(), nil
+}
+
+func unexpectedEOF(err error) error {
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
+
+// EncodeTokens writes tokens to w using encoding/gob, with all their fields.
+func EncodeTokens(w io.Writer, tokens []Token) error {
+	plain := make([]plainToken, len(tokens))
+	for i, t := range tokens {
+		plain[i] = plainToken(t)
+	}
+	return gob.NewEncoder(w).Encode(plain)
+}
+
+// DecodeTokens reads tokens written by EncodeTokens from r.
+func DecodeTokens(r io.Reader) ([]Token, error) {
+	var plain []plainToken
+	if err := gob.NewDecoder(r).Decode(&plain); err != nil {
+		return nil, err
+	}
+	tokens := make([]Token, len(plain))
+	for i, t := range plain {
+		tokens[i] = Token(t)
+	}
+	return tokens, nil
+}
@@ -0,0 +1,190 @@
+package lexer
+
+import (
+	"bytes"
+	"encoding/gob"
+	"fmt"
+	"io"
+	"testing"
+)
+
+func Test_TokenWriter(t *testing.T) {
+	cases := []struct {
+		format Format
+		out    string
+	}{
+		{JSONL, "{\"Type\":0,\"Value\":\"123\",\"Line\":1,\"Col\":1,\"Offset\":0,\"Index\":0}\n{\"Type\":1,\"Value\":\".\",\"Line\":1,\"Col\":4,\"Offset\":3,\"Index\":1}\n{\"Type\":2,\"Value\":\"a\",\"Line\":1,\"Col\":5,\"Offset\":4,\"Index\":2}\n"},
+		{Binary, "\x00\x01\x01\x00\x00\x03123\x02\x01\x04\x03\x01\x01.\x04\x01\x05\x04\x02\x01a"},
+		{CSV, "0,123,1,1,0,0\n1,.,1,4,3,1\n2,a,1,5,4,2\n"},
+		{Protobuf, "\x13\x12\x06number\x1a\x03123\x22\x04\x08\x01\x10\x01\x0f\x08\x01\x1a\x01.\x22\x06\x08\x01\x10\x04\x18\x03\x28\x01\x0f\x08\x02\x1a\x01a\x22\x06\x08\x01\x10\x05\x18\x04\x28\x02"},
+	}
+
+	for _, c := range cases {
+		var out bytes.Buffer
+		w := NewTokenWriter(&out, c.format)
+		w.Names = map[TokenType]string{NumberToken: "number"}
+		l := New(bytes.NewBufferString("123.a"), numberState)
+		l.Scan(w.Handle)
+		if err := w.Flush(); err != nil {
+			t.Errorf("Unexpected error %v", err)
+			return
+		}
+		if out.String() != c.out {
+			t.Errorf("Expected %q but got %q", c.out, out.String())
+			return
+		}
+	}
+}
+
+func Test_TokenReader(t *testing.T) {
+	for _, format := range []Format{JSONL, Binary, CSV, Protobuf} {
+		var out bytes.Buffer
+		w := NewTokenWriter(&out, format)
+		l := New(bytes.NewBufferString("123.hello  675.world"), numberState)
+		var expected []Token
+		l.Scan(func(tok Token) {
+			expected = append(expected, tok)
+			w.Handle(tok)
+		})
+		w.Flush()
+
+		var tokens []Token
+		r := NewTokenReader(&out, format)
+		r.Scan(func(tok Token) {
+			tokens = append(tokens, tok)
+		})
+		if r.Err != nil {
+			t.Errorf("Unexpected error %v", r.Err)
+			return
+		}
+		if len(tokens) != len(expected) {
+			t.Errorf("Expected %v tokens but got %v", len(expected), len(tokens))
+			return
+		}
+		for i := range expected {
+			if tokens[i] != expected[i] {
+				t.Errorf("Expected %v but got %v", expected[i], tokens[i])
+				return
+			}
+		}
+	}
+}
+
+func Test_TokenReaderTruncated(t *testing.T) {
+	r := NewTokenReader(bytes.NewBufferString("\x00\x01\x01\x00\x00\x0312"), Binary)
+	if tok := r.NextToken(); tok != nil {
+		t.Errorf("Expected a nil token, but got %v", *tok)
+		return
+	}
+	if r.Err == nil {
+		t.Error("Expected an error to be on the reader, but none found.")
+		return
+	}
+}
+
+func Test_TokenReaderCorruptLength(t *testing.T) {
+	for _, c := range []struct {
+		src      string
+		expected string
+	}{
+		{"\x00\x01\x01\x00\x00\xff\xff\xff\xff\xff\xff\xff\xff\x7f", "invalid token stream length 9223372036854775807"},
+		{"\x00\x01\x01\x00\x00\xff\xff\xff\xff\x07ab", "unexpected EOF"},
+	} {
+		r := NewTokenReader(bytes.NewBufferString(c.src), Binary)
+		if tok := r.NextToken(); tok != nil || fmt.Sprint(r.Err) != c.expected {
+			t.Errorf("Expected %v but got %v %v", c.expected, tok, r.Err)
+			return
+		}
+	}
+}
+
+func Test_TokenReaderProtobufLength(t *testing.T) {
+	for _, c := range []struct {
+		src      string
+		expected string
+	}{
+		{"\xff\xff\xff\xff\xff\xff\xff\xff\xff\x01", "invalid token stream length 18446744073709551615"},
+		{"\x13\x12\x06number", "unexpected EOF"},
+		{"\x80", "unexpected EOF"},
+	} {
+		r := NewTokenReader(bytes.NewBufferString(c.src), Protobuf)
+		if tok := r.NextToken(); tok != nil || fmt.Sprint(r.Err) != c.expected {
+			t.Errorf("Expected %v but got %v %v", c.expected, tok, r.Err)
+			return
+		}
+	}
+}
+
+func Test_GobTokens(t *testing.T) {
+	l := New(bytes.NewBufferString("123.hello  675.world"), numberState, WithProvenance())
+	var expected []Token
+	l.Scan(func(tok Token) {
+		expected = append(expected, tok)
+	})
+
+	var out bytes.Buffer
+	if err := EncodeTokens(&out, expected); err != nil {
+		t.Errorf("Unexpected error %v", err)
+		return
+	}
+	tokens, err := DecodeTokens(&out)
+	if err != nil {
+		t.Errorf("Unexpected error %v", err)
+		return
+	}
+	if fmt.Sprint(tokens) != fmt.Sprint(expected) {
+		t.Errorf("Expected %v but got %v", expected, tokens)
+		return
+	}
+}
+
+func Test_GobInterface(t *testing.T) {
+	var out bytes.Buffer
+	var v interface{} = Token{Type: IdentToken, Value: "a", Line: 1, Col: 1}
+	if err := gob.NewEncoder(&out).Encode(&v); err != nil {
+		t.Errorf("Unexpected error %v", err)
+		return
+	}
+	var got interface{}
+	if err := gob.NewDecoder(&out).Decode(&got); err != nil || got != v {
+		t.Errorf("Expected %v but got %v %v", v, got, err)
+		return
+	}
+}
+
+func Test_TokenReaderNames(t *testing.T) {
+	var out bytes.Buffer
+	w := NewTokenWriter(&out, Protobuf)
+	w.Names = map[TokenType]string{NumberToken: "number", IdentToken: "ident"}
+	New(bytes.NewBufferString("123.a"), numberState).Scan(w.Handle)
+	w.Flush()
+
+	r := NewTokenReader(&out, Protobuf)
+	r.Scan(func(Token) {})
+	if r.Err != nil || fmt.Sprint(r.Names) != fmt.Sprint(w.Names) {
+		t.Errorf("Expected %v but got %v %v", w.Names, r.Names, r.Err)
+		return
+	}
+}
+
+func Test_TokenReaderRead(t *testing.T) {
+	r := NewTokenReader(bytes.NewBufferString("0,1,1,1,0,0\n1,.,1,x,1,1\n"), CSV)
+	if tok, err := r.ReadToken(); err != nil || tok.Value != "1" {
+		t.Errorf("Expected %q but got %q %v", "1", tok.Value, err)
+		return
+	}
+	if _, err := r.ReadToken(); err == nil || err == io.EOF {
+		t.Errorf("Expected a decoding error but got %v", err)
+		return
+	}
+	r = NewTokenReader(bytes.NewBufferString("0,1,1,1,0,0\n1,.,1,x,1,1\n"), CSV)
+	if err := r.Scan(func(Token) {}); err == nil || err != r.Err {
+		t.Errorf("Expected %v but got %v", r.Err, err)
+		return
+	}
+	r = NewTokenReader(bytes.NewBufferString(""), CSV)
+	if _, err := r.ReadToken(); err != io.EOF {
+		t.Errorf("Expected %v but got %v", io.EOF, err)
+		return
+	}
+}
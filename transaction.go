@@ -0,0 +1,64 @@
+package lexer
+
+// transaction is a speculative region started by Begin.
+type transaction struct {
+	saved  *L      // the lexer at Begin
+	tokens []Token // emitted since Begin
+	lazy   int     // number of lazy tokens at Begin
+}
+
+// Begin starts a speculative region: the tokens emitted until the matching
+// Commit are held, and the input consumed can be read again after a Rollback.
+// The regions can be nested. The source of l is read with a cursor, see Clone:
+// the input read during a region is buffered until it ends.
+func (l *L) Begin() {
+	tx := transaction{saved: l.Clone()}
+	if l.lazy != nil {
+		tx.lazy = len(l.lazy.Tokens)
+	}
+	l.transactions = append(l.transactions, tx)
+}
+
+// Commit ends the innermost region and keeps its tokens,
+// they are delivered once the outermost region is committed.
+// Without a region it reports an error.
+func (l *L) Commit() {
+	if len(l.transactions) == 0 {
+		l.Error("commit without begin")
+		return
+	}
+	tx := l.transactions[len(l.transactions)-1]
+	l.transactions = l.transactions[:len(l.transactions)-1]
+	if len(l.transactions) > 0 {
+		outer := &l.transactions[len(l.transactions)-1]
+		outer.tokens = append(outer.tokens, tx.tokens...)
+		return
+	}
+	for _, tok := range tx.tokens {
+		l.dispatch(tok)
+	}
+}
+
+// Rollback ends the innermost region and undoes it: its tokens are dropped,
+// the position, the modes and the diagnostics of l are restored as of Begin.
+// The errors are not reported again to the ErrorHandler.
+// Without a region it reports an error.
+func (l *L) Rollback() {
+	if len(l.transactions) == 0 {
+		l.Error("rollback without begin")
+		return
+	}
+	tx := l.transactions[len(l.transactions)-1]
+	l.transactions = l.transactions[:len(l.transactions)-1]
+	s := tx.saved
+	l.src, l.buf, l.start, l.position = s.src, s.buf, s.start, s.position
+	l.value, l.built = s.value, s.built
+	l.line, l.col, l.offset, l.index = s.line, s.col, s.offset, s.index
+	l.readbytes, l.dropped, l.widths = s.readbytes, s.dropped, s.widths
+	l.lineText, l.rewind, l.modes = s.lineText, s.rewind, s.modes
+	l.Err, l.errors, l.diagnostics, l.overflow = s.Err, s.errors, s.diagnostics, s.overflow
+	l.file, l.includes = s.file, s.includes
+	if l.lazy != nil {
+		l.lazy.Tokens = l.lazy.Tokens[:tx.lazy]
+	}
+}
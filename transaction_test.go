@@ -0,0 +1,74 @@
+package lexer
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"unicode"
+)
+
+func Test_Transaction(t *testing.T) {
+	var state StateFunc
+	state = func(l *L) StateFunc {
+		switch r := l.Peek(); {
+		case r == EOFRune:
+			return nil
+		case r == ' ':
+			l.Next()
+			l.Ignore()
+		case unicode.IsDigit(r):
+			// a member access, or a float
+			l.Begin()
+			l.Take("0123456789")
+			l.Emit(NumberToken)
+			if l.Next() == '.' {
+				l.Emit(OpToken)
+				if unicode.IsLetter(l.Peek()) {
+					l.Take("abcdefghijklmnopqrstuvwxyz")
+					l.Emit(IdentToken)
+					l.Commit()
+					return state
+				}
+			}
+			l.Rollback()
+			l.Take("0123456789.")
+			l.Emit(NumberToken)
+		default:
+			l.Next()
+			l.Emit(OpToken)
+		}
+		return state
+	}
+	for _, l := range []*L{
+		NewString("1.x 2.3 4", state),
+		New(strings.NewReader("1.x 2.3 4"), state),
+	} {
+		var got []string
+		l.Scan(func(tok Token) {
+			got = append(got, fmt.Sprintf("%v@%v:%v", tok.Value, tok.Offset, tok.Index))
+		})
+		expected := "[1@0:0 .@1:1 x@2:2 2.3@4:3 4@8:4]"
+		if fmt.Sprint(got) != expected {
+			t.Errorf("Expected %v but got %v", expected, got)
+			return
+		}
+	}
+}
+
+func Test_TransactionUnbalanced(t *testing.T) {
+	l := NewString("a", nil)
+	var errs []string
+	l.ErrorHandler = func(e string) {
+		errs = append(errs, e)
+	}
+	l.Commit()
+	l.Rollback()
+	l.Begin()
+	l.Commit()
+	l.Commit()
+	expected := "[commit without begin rollback without begin commit without begin]"
+	if fmt.Sprint(errs) != expected {
+		t.Errorf("Expected %v but got %v", expected, errs)
+		return
+	}
+}
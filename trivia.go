@@ -0,0 +1,61 @@
+package lexer
+
+import "strings"
+
+// TriviaToken is a significant token with the trivia around it,
+// such as whitespaces and comments.
+type TriviaToken struct {
+	Token
+	// Leading are the trivia preceding the token, from the previous line end.
+	Leading []Token
+	// Trailing are the trivia following the token on its line,
+	// up to and including the newline.
+	Trailing []Token
+}
+
+// TriviaFilter attaches the trivia tokens to the adjacent significant tokens,
+// rather than delivering them inline. It delays each token until the next
+// significant token is handled, Flush must be called at the end of the stream.
+type TriviaFilter struct {
+	// Trivia are the types of the trivia tokens.
+	Trivia map[TokenType]bool
+	// Handler receives the significant tokens.
+	Handler func(t TriviaToken)
+
+	pending  *TriviaToken
+	leading  []Token
+	lineDone bool // the trailing trivia of pending ended with a newline
+}
+
+// Handle filters t, it is suitable as a TokenHandler or a Scan callback.
+func (f *TriviaFilter) Handle(t Token) {
+	if !f.Trivia[t.Type] {
+		if f.pending != nil {
+			f.Handler(*f.pending)
+		}
+		f.pending = &TriviaToken{Token: t, Leading: f.leading}
+		f.leading, f.lineDone = nil, false
+		return
+	}
+	if f.pending != nil && !f.lineDone {
+		f.pending.Trailing = append(f.pending.Trailing, t)
+		f.lineDone = strings.Contains(t.Value, "\n")
+		return
+	}
+	f.leading = append(f.leading, t)
+}
+
+// Flush delivers the last token, with the remaining trivia as trailing.
+// If no significant token was handled, the trivia are delivered
+// as the leading trivia of a zero Token.
+func (f *TriviaFilter) Flush() {
+	if f.pending == nil {
+		if len(f.leading) > 0 {
+			f.Handler(TriviaToken{Leading: f.leading})
+		}
+	} else {
+		f.pending.Trailing = append(f.pending.Trailing, f.leading...)
+		f.Handler(*f.pending)
+	}
+	f.pending, f.leading, f.lineDone = nil, nil, false
+}
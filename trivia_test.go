@@ -0,0 +1,41 @@
+package lexer
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+)
+
+func Test_TriviaFilter(t *testing.T) {
+	const (
+		SpaceToken TokenType = iota + 10
+		CommentToken
+	)
+	rules, _ := NewRules(
+		Rule{Type: IdentToken, Pattern: `[a-z]+`},
+		Rule{Type: CommentToken, Pattern: `#[^\n]*`},
+		Rule{Type: SpaceToken, Pattern: `\s+`},
+	)
+	var got []string
+	f := &TriviaFilter{
+		Trivia: map[TokenType]bool{SpaceToken: true, CommentToken: true},
+		Handler: func(t TriviaToken) {
+			var leading, trailing []string
+			for _, l := range t.Leading {
+				leading = append(leading, l.Value)
+			}
+			for _, l := range t.Trailing {
+				trailing = append(trailing, l.Value)
+			}
+			got = append(got, fmt.Sprintf("%q%q%q", leading, t.Value, trailing))
+		},
+	}
+	New(bytes.NewBufferString("# doc\na # end\n\n  b c\n"), rules.State).Scan(f.Handle)
+	f.Flush()
+
+	expected := `[["# doc" "\n"]"a"[" " "# end" "\n\n  "] []"b"[" "] []"c"["\n"]]`
+	if fmt.Sprint(got) != expected {
+		t.Errorf("Expected %v but got %v", expected, got)
+		return
+	}
+}
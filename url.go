@@ -0,0 +1,47 @@
+package lexer
+
+import "strings"
+
+// urlPChars are the characters of RFC 3986 path segments, besides the
+// percent-encoded octets: unreserved, sub-delims, ':' and '@'.
+var urlPChars = NewRuneSet("-._~!$&'()*+,;=:@").AddRange('a', 'z').AddRange('A', 'Z').AddRange('0', '9')
+
+// ScanPctEncoded consumes the percent-encoded octet at the current position,
+// such as %2F, and returns its value. If there is none nothing is consumed
+// and ok is false.
+func ScanPctEncoded(l *L) (b byte, ok bool) {
+	if l.Peek() != '%' {
+		return 0, false
+	}
+	l.Next()
+	hi := digitValue(l.Next())
+	lo := digitValue(l.Next())
+	if hi < 16 && lo < 16 {
+		return byte(hi<<4 | lo), true
+	}
+	l.Rewind()
+	l.Rewind()
+	l.Rewind()
+	return 0, false
+}
+
+// TakeURLSegment consumes the characters of an RFC 3986 path segment,
+// the percent-encoded octets included, and the characters of extra.
+// extra is "/?" for a query or a fragment.
+func TakeURLSegment(l *L, extra string) {
+	for {
+		r := l.Peek()
+		switch {
+		case r == EOFRune:
+			return
+		case r == '%':
+			if _, ok := ScanPctEncoded(l); !ok {
+				return
+			}
+		case urlPChars.Contains(r) || strings.ContainsRune(extra, r):
+			l.Next()
+		default:
+			return
+		}
+	}
+}
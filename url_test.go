@@ -0,0 +1,48 @@
+package lexer
+
+import (
+	"fmt"
+	"testing"
+)
+
+func Test_ScanPctEncoded(t *testing.T) {
+	for _, c := range []struct {
+		src   string
+		b     byte
+		ok    bool
+		value string
+	}{
+		{"%2Fa", '/', true, "%2F"},
+		{"%e9", 0xe9, true, "%e9"},
+		{"%G1", 0, false, ""},
+		{"%1", 0, false, ""},
+		{"a", 0, false, ""},
+	} {
+		l := NewString(c.src, nil)
+		b, ok := ScanPctEncoded(l)
+		got := fmt.Sprintf("%v %v %q", b, ok, l.Current())
+		expected := fmt.Sprintf("%v %v %q", c.b, c.ok, c.value)
+		if got != expected {
+			t.Errorf("Expected %v but got %v", expected, got)
+			return
+		}
+	}
+}
+
+func Test_TakeURLSegment(t *testing.T) {
+	for _, c := range []struct {
+		src, extra, value string
+	}{
+		{"a%20b:c@d/e", "", "a%20b:c@d"},
+		{"q=1&r=a/b?c#f", "/?", "q=1&r=a/b?c"},
+		{"a%zz", "", "a"},
+		{"é", "", ""},
+	} {
+		l := NewString(c.src, nil)
+		TakeURLSegment(l, c.extra)
+		if l.Current() != c.value {
+			t.Errorf("Expected %q but got %q", c.value, l.Current())
+			return
+		}
+	}
+}